@@ -0,0 +1,144 @@
+package edb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// AlterTableStmt builds `ALTER TABLE ...`.
+//
+// SQLite and MSSQL allow one change per statement,
+// so several changes are written as several statements separated by `;`.
+type AlterTableStmt struct {
+	runner
+	EventReceiver
+	Dialect
+
+	Table       string
+	AddColumns  []*ColumnDef
+	DropColumns []string
+	comments    Comments
+}
+
+type AlterTableBuilder = AlterTableStmt
+
+func (b *AlterTableStmt) Build(d Dialect, buf Buffer) error {
+	if b.Table == "" {
+		return ErrTableNotSpecified
+	}
+
+	if len(b.AddColumns) == 0 && len(b.DropColumns) == 0 {
+		return ErrColumnNotSpecified
+	}
+
+	err := b.comments.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
+	separate := d == dialect.SQLite3 || d == dialect.MSSQL
+	n := 0
+	next := func() {
+		if n == 0 || separate {
+			if n > 0 {
+				buf.WriteString("; ")
+			}
+			buf.WriteString("ALTER TABLE ")
+			buf.WriteString(d.QuoteIdent(b.Table))
+			buf.WriteString(" ")
+		} else {
+			buf.WriteString(", ")
+		}
+		n++
+	}
+
+	for _, col := range b.AddColumns {
+		next()
+		if d == dialect.MSSQL {
+			buf.WriteString("ADD ")
+		} else {
+			buf.WriteString("ADD COLUMN ")
+		}
+		err := col.Build(d, buf)
+		if err != nil {
+			return err
+		}
+		if col.RefTable == "" {
+			continue
+		}
+		if d == dialect.MySQL {
+			// MySQL ignores REFERENCES in a column definition
+			buf.WriteString(", ADD ")
+			fk := ForeignKeyDef{
+				Column:    []string{col.Name},
+				RefTable:  col.RefTable,
+				RefColumn: []string{col.RefColumn},
+			}
+			fk.Build(d, buf)
+		} else {
+			buf.WriteString(" REFERENCES ")
+			buf.WriteString(d.QuoteIdent(col.RefTable))
+			buf.WriteString(" ")
+			buildIdentList(d, buf, []string{col.RefColumn})
+		}
+	}
+
+	for _, col := range b.DropColumns {
+		next()
+		buf.WriteString("DROP COLUMN ")
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	return nil
+}
+
+// AlterTable creates an AlterTableStmt.
+func AlterTable(table string) *AlterTableStmt {
+	return &AlterTableStmt{
+		Table: table,
+	}
+}
+
+// AlterTable creates an AlterTableStmt.
+func (sess *Session) AlterTable(table string) *AlterTableStmt {
+	b := AlterTable(table)
+	b.runner = sess
+	b.EventReceiver = sess.EventReceiver
+	b.Dialect = sess.Dialect
+	return b
+}
+
+// AlterTable creates an AlterTableStmt.
+func (tx *Tx) AlterTable(table string) *AlterTableStmt {
+	b := AlterTable(table)
+	b.runner = tx
+	b.EventReceiver = tx.EventReceiver
+	b.Dialect = tx.Dialect
+	return b
+}
+
+// AddColumn adds `ADD COLUMN ...`.
+func (b *AlterTableStmt) AddColumn(column *ColumnDef) *AlterTableStmt {
+	b.AddColumns = append(b.AddColumns, column)
+	return b
+}
+
+// DropColumn adds `DROP COLUMN ...`.
+func (b *AlterTableStmt) DropColumn(column string) *AlterTableStmt {
+	b.DropColumns = append(b.DropColumns, column)
+	return b
+}
+
+func (b *AlterTableStmt) Comment(comment string) *AlterTableStmt {
+	b.comments = b.comments.Append(comment)
+	return b
+}
+
+func (b *AlterTableStmt) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+func (b *AlterTableStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	return exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
+}
@@ -0,0 +1,234 @@
+package edb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// ErrInvalidArray is returned when scanning a malformed PostgreSQL array.
+var ErrInvalidArray = errors.New("edb: invalid array")
+
+// ArrayElem is an element type of Array.
+// ~[16]byte covers uuid types such as github.com/google/uuid.UUID.
+type ArrayElem interface {
+	~int64 | ~float64 | ~string | ~[16]byte
+}
+
+// Array is a one-dimensional PostgreSQL array like bigint[], text[],
+// double precision[] or uuid[].
+//
+// Unlike other values, an Array is always sent as one bound parameter,
+// so large lists do not grow the query.
+type Array[T ArrayElem] []T
+
+// bindValuer is implemented by values which are sent as bound parameters
+// instead of being interpolated.
+type bindValuer interface {
+	driver.Valuer
+	bindValue()
+}
+
+func (Array[T]) bindValue() {}
+
+// Value implements the driver Valuer interface.
+// A nil Array is NULL.
+func (a Array[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var buf strings.Builder
+	buf.WriteString("{")
+	for i := range a {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		v := reflect.ValueOf(a[i])
+		switch v.Kind() {
+		case reflect.Int64:
+			buf.WriteString(strconv.FormatInt(v.Int(), 10))
+		case reflect.Float64:
+			f := v.Float()
+			switch {
+			case math.IsNaN(f):
+				buf.WriteString("NaN")
+			case math.IsInf(f, 1):
+				buf.WriteString("Infinity")
+			case math.IsInf(f, -1):
+				buf.WriteString("-Infinity")
+			default:
+				buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+			}
+		case reflect.String:
+			buf.WriteString(`"`)
+			buf.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v.String()))
+			buf.WriteString(`"`)
+		case reflect.Array:
+			var b [16]byte
+			reflect.Copy(reflect.ValueOf(&b).Elem(), v)
+			buf.WriteString(formatUUID(b))
+		}
+	}
+	buf.WriteString("}")
+	return buf.String(), nil
+}
+
+// Scan implements the Scanner interface.
+func (a *Array[T]) Scan(value interface{}) error {
+	var src string
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	default:
+		return ErrNotSupported
+	}
+
+	elems, err := parseArray(src)
+	if err != nil {
+		return err
+	}
+	arr := make(Array[T], len(elems))
+	for i, elem := range elems {
+		v := reflect.ValueOf(&arr[i]).Elem()
+		switch v.Kind() {
+		case reflect.Int64:
+			n, err := strconv.ParseInt(elem, 10, 64)
+			if err != nil {
+				return err
+			}
+			v.SetInt(n)
+		case reflect.Float64:
+			f, err := strconv.ParseFloat(strings.TrimSuffix(elem, "inity"), 64)
+			if err != nil {
+				return err
+			}
+			v.SetFloat(f)
+		case reflect.String:
+			v.SetString(elem)
+		case reflect.Array:
+			b, err := parseUUID(elem)
+			if err != nil {
+				return err
+			}
+			reflect.Copy(v, reflect.ValueOf(b[:]))
+		}
+	}
+	*a = arr
+	return nil
+}
+
+// parseArray splits a one-dimensional array literal like {1,"a b",NULL}.
+// NULL elements are not supported.
+func parseArray(src string) ([]string, error) {
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, ErrInvalidArray
+	}
+	src = src[1 : len(src)-1]
+	if src == "" {
+		return []string{}, nil
+	}
+	var elems []string
+	for {
+		var elem strings.Builder
+		if strings.HasPrefix(src, `"`) {
+			i := 1
+			for ; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+				if i < len(src) {
+					elem.WriteByte(src[i])
+				}
+			}
+			if i >= len(src) {
+				return nil, ErrInvalidArray
+			}
+			src = src[i+1:]
+		} else {
+			i := strings.IndexByte(src, ',')
+			if i < 0 {
+				i = len(src)
+			}
+			if src[:i] == "NULL" || strings.ContainsAny(src[:i], "{}") {
+				return nil, ErrInvalidArray
+			}
+			elem.WriteString(src[:i])
+			src = src[i:]
+		}
+		elems = append(elems, elem.String())
+		if src == "" {
+			return elems, nil
+		}
+		if src[0] != ',' {
+			return nil, ErrInvalidArray
+		}
+		src = src[1:]
+	}
+}
+
+func formatUUID(b [16]byte) string {
+	s := hex.EncodeToString(b[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+func parseUUID(s string) ([16]byte, error) {
+	var b [16]byte
+	_, err := hex.Decode(b[:], []byte(strings.Replace(strings.Trim(s, "{}"), "-", "", -1)))
+	return b, err
+}
+
+// EqAny is `= ANY(?)` on PostgreSQL, where value is sent as one array
+// parameter. Other dialects fall back to Eq, which translates to `IN`.
+func EqAny[T ArrayElem](column string, value []T) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if d != dialect.PostgreSQL {
+			return Eq(column, value).Build(d, buf)
+		}
+		if value == nil {
+			value = []T{}
+		}
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(" = ANY(")
+		buf.WriteString(placeholder)
+		buf.WriteString(")")
+		buf.WriteValue(Array[T](value))
+		return nil
+	})
+}
+
+func buildArrayCmp[T ArrayElem](d Dialect, buf Buffer, pred string, column string, value []T) error {
+	if d != dialect.PostgreSQL {
+		return ErrNotSupported
+	}
+	if value == nil {
+		value = []T{}
+	}
+	return buildCmp(d, buf, pred, column, Array[T](value))
+}
+
+// ArrayContains is `@>` on a PostgreSQL array column.
+// It checks that the column contains all elements of value.
+func ArrayContains[T ArrayElem](column string, value []T) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildArrayCmp(d, buf, "@>", column, value)
+	})
+}
+
+// ArrayOverlap is `&&` on a PostgreSQL array column.
+// It checks that the column has any element of value.
+func ArrayOverlap[T ArrayElem](column string, value []T) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildArrayCmp(d, buf, "&&", column, value)
+	})
+}
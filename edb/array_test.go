@@ -0,0 +1,139 @@
+package edb
+
+import (
+	"math"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+type testUUID [16]byte
+
+func TestArrayValuerScanner(t *testing.T) {
+	v, err := Array[int64]{1, -2, 3}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "{1,-2,3}", v)
+
+	v, err = Array[string]{"a", `b "c"`, `d\e`, "f,g"}.Value()
+	require.NoError(t, err)
+	require.Equal(t, `{"a","b \"c\"","d\\e","f,g"}`, v)
+
+	v, err = Array[float64]{1.5, math.Inf(1)}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "{1.5,Infinity}", v)
+
+	id := testUUID{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}
+	v, err = Array[testUUID]{id}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "{12345678-9abc-def0-1234-56789abcdef0}", v)
+
+	v, err = Array[int64](nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	var ints Array[int64]
+	require.NoError(t, ints.Scan([]byte("{1,-2,3}")))
+	require.Equal(t, Array[int64]{1, -2, 3}, ints)
+
+	var strs Array[string]
+	require.NoError(t, strs.Scan(`{a,"b \"c\"","d\\e","f,g"}`))
+	require.Equal(t, Array[string]{"a", `b "c"`, `d\e`, "f,g"}, strs)
+
+	var floats Array[float64]
+	require.NoError(t, floats.Scan("{1.5,-Infinity}"))
+	require.Equal(t, Array[float64]{1.5, math.Inf(-1)}, floats)
+
+	var uuids Array[testUUID]
+	require.NoError(t, uuids.Scan("{12345678-9abc-def0-1234-56789abcdef0}"))
+	require.Equal(t, Array[testUUID]{id}, uuids)
+
+	require.NoError(t, ints.Scan("{}"))
+	require.Equal(t, Array[int64]{}, ints)
+	require.NoError(t, ints.Scan(nil))
+	require.Nil(t, ints)
+
+	require.Equal(t, ErrInvalidArray, strs.Scan("{a,NULL}"))
+	require.Equal(t, ErrInvalidArray, ints.Scan("{{1},{2}}"))
+}
+
+func TestArrayCondition(t *testing.T) {
+	for _, test := range []struct {
+		cond  Builder
+		d     Dialect
+		query string
+		value []interface{}
+	}{
+		{
+			cond:  EqAny("id", []int64{1, 2, 3}),
+			d:     dialect.PostgreSQL,
+			query: `"id" = ANY($1)`,
+			value: []interface{}{Array[int64]{1, 2, 3}},
+		},
+		{
+			cond:  EqAny("id", []int64{1, 2, 3}),
+			d:     dialect.MySQL,
+			query: "`id` IN (1,2,3)",
+		},
+		{
+			cond:  ArrayContains("tags", []string{"a"}),
+			d:     dialect.PostgreSQL,
+			query: `"tags" @> $1`,
+			value: []interface{}{Array[string]{"a"}},
+		},
+		{
+			cond:  ArrayOverlap("tags", []string{"a", "b"}),
+			d:     dialect.PostgreSQL,
+			query: `"tags" && $1`,
+			value: []interface{}{Array[string]{"a", "b"}},
+		},
+		{
+			cond:  Eq("tags", Array[string]{"a"}),
+			d:     dialect.PostgreSQL,
+			query: `"tags" = $1`,
+			value: []interface{}{Array[string]{"a"}},
+		},
+	} {
+		i := interpolator{
+			Buffer:       NewBuffer(),
+			Dialect:      test.d,
+			IgnoreBinary: true,
+		}
+		err := i.encodePlaceholder(test.cond, true)
+		require.NoError(t, err)
+		require.Equal(t, test.query, i.String())
+		require.Equal(t, test.value, i.Value())
+	}
+
+	err := ArrayContains("tags", []string{"a"}).Build(dialect.MySQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestArraySQLMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	mock.ExpectQuery(`SELECT id FROM people WHERE \("id" = ANY\(\$1\)\)`).
+		WithArgs("{1,2,3}").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3))
+	id, err := sess.Select("id").From("people").Where(EqAny("id", []int64{1, 2, 3})).ReturnInt64s()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, id)
+
+	mock.ExpectQuery(`SELECT tags FROM people`).
+		WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow([]byte(`{a,b}`)))
+	var tags Array[string]
+	err = sess.Select("tags").From("people").LoadOne(&tags)
+	require.NoError(t, err)
+	require.Equal(t, Array[string]{"a", "b"}, tags)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
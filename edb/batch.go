@@ -0,0 +1,178 @@
+package edb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BatchStmt applies an UPDATE or DELETE to a large table in chunks of
+// rows ordered by a key column, so each statement only locks a few rows.
+//
+// Each chunk first selects its last key with
+// `SELECT key FROM t WHERE ... AND key > last ORDER BY key LIMIT 1 OFFSET n-1`,
+// then runs the statement with `key > last AND key <= upper` added to WHERE.
+// After every chunk, LastKey is updated and a "dbr.batch.chunk" event is sent
+// with the table, last_key, rows and total, so a purge that stopped can
+// be resumed from the last processed key with Resume.
+type BatchStmt struct {
+	stmt batchable
+
+	// Key is the column that orders the rows, usually the primary key.
+	Key string
+	// Size is the number of rows in a chunk.
+	Size uint64
+	// Sleep is the pause between chunks.
+	Sleep time.Duration
+	// LastKey is the upper key of the last completed chunk,
+	// or nil to start from the first row. The final chunk, which has
+	// fewer than Size rows, has no upper key and leaves it unchanged.
+	LastKey interface{}
+}
+
+// batchable is a statement that can be run in chunks.
+type batchable interface {
+	Builder
+	batchTable() (string, []Builder, *SoftDelete, error)
+	batchRunner() (runner, EventReceiver, Dialect)
+	batchChunk(cond []Builder) Builder
+}
+
+// Batch runs the statement in chunks of size rows ordered by key.
+func (b *UpdateStmt) Batch(key string, size uint64) *BatchStmt {
+	return &BatchStmt{stmt: b, Key: key, Size: size}
+}
+
+// Batch runs the statement in chunks of size rows ordered by key.
+func (b *DeleteStmt) Batch(key string, size uint64) *BatchStmt {
+	return &BatchStmt{stmt: b, Key: key, Size: size}
+}
+
+func (b *UpdateStmt) batchTable() (string, []Builder, *SoftDelete, error) {
+	if b.raw.Query != "" || len(b.JoinTable) > 0 || b.LimitCount >= 0 {
+		return "", nil, nil, ErrNotSupported
+	}
+	return b.Table, b.WhereCond, b.SoftDelete, nil
+}
+
+func (b *UpdateStmt) batchRunner() (runner, EventReceiver, Dialect) {
+	return b.runner, b.EventReceiver, b.Dialect
+}
+
+func (b *UpdateStmt) batchChunk(cond []Builder) Builder {
+	c := b.Clone()
+	c.WhereCond = append(c.WhereCond, cond...)
+	return c
+}
+
+func (b *DeleteStmt) batchTable() (string, []Builder, *SoftDelete, error) {
+	if b.raw.Query != "" || len(b.JoinTable) > 0 || b.LimitCount >= 0 {
+		return "", nil, nil, ErrNotSupported
+	}
+	return b.Table, b.WhereCond, b.SoftDelete, nil
+}
+
+func (b *DeleteStmt) batchRunner() (runner, EventReceiver, Dialect) {
+	return b.runner, b.EventReceiver, b.Dialect
+}
+
+func (b *DeleteStmt) batchChunk(cond []Builder) Builder {
+	c := b.Clone()
+	c.WhereCond = append(c.WhereCond, cond...)
+	return c
+}
+
+// Throttle sets the pause between chunks.
+func (b *BatchStmt) Throttle(d time.Duration) *BatchStmt {
+	b.Sleep = d
+	return b
+}
+
+// Resume starts after the row with key, which was reported as last_key.
+func (b *BatchStmt) Resume(key interface{}) *BatchStmt {
+	b.LastKey = key
+	return b
+}
+
+// Exec runs all chunks, and returns the total number of affected rows.
+func (b *BatchStmt) Exec() (int64, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext runs all chunks, and returns the total number of affected rows.
+// If it fails, LastKey is the key of the last completed chunk.
+func (b *BatchStmt) ExecContext(ctx context.Context) (int64, error) {
+	if b.Key == "" {
+		return 0, ErrColumnNotSpecified
+	}
+	if b.Size == 0 {
+		return 0, ErrNotSupported
+	}
+	table, where, softDelete, err := b.stmt.batchTable()
+	if err != nil {
+		return 0, err
+	}
+	runner, log, d := b.stmt.batchRunner()
+
+	var total int64
+	for {
+		var cond []Builder
+		if b.LastKey != nil {
+			cond = append(cond, Gt(b.Key, b.LastKey))
+		}
+
+		sel := Select(I(b.Key)).From(table)
+		sel.WhereCond = append(where[:len(where):len(where)], cond...)
+		sel.runner, sel.EventReceiver, sel.Dialect = runner, log, d
+		sel.SoftDelete = softDelete
+		sel.Order = []Builder{I(b.Key)}
+		sel.LimitCount = 1
+		sel.OffsetCount = int64(b.Size) - 1
+
+		var upper interface{}
+		err := sel.LoadOneContext(ctx, &upper)
+		last := err == ErrNotFound
+		if err != nil && !last {
+			return total, err
+		}
+		if !last {
+			if v, ok := upper.([]byte); ok {
+				upper = string(v)
+			}
+			cond = append(cond, Lte(b.Key, upper))
+		}
+
+		result, err := exec(ctx, runner, log, b.stmt.batchChunk(cond), d)
+		if err != nil {
+			return total, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if !last {
+			b.LastKey = upper
+		}
+		log.EventKv("dbr.batch.chunk", kvs{
+			"table":    table,
+			"last_key": fmt.Sprint(b.LastKey),
+			"rows":     strconv.FormatInt(n, 10),
+			"total":    strconv.FormatInt(total, 10),
+		})
+		if last {
+			return total, nil
+		}
+
+		if b.Sleep > 0 {
+			t := time.NewTimer(b.Sleep)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return total, ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package edb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testBatchReceiver struct {
+	NullEventReceiver
+	chunks []map[string]string
+}
+
+func (r *testBatchReceiver) EventKv(eventName string, kvs map[string]string) {
+	if eventName == "dbr.batch.chunk" {
+		r.chunks = append(r.chunks, kvs)
+	}
+}
+
+func TestSQLite3Batch(t *testing.T) {
+	sess := memorySQLite(t)
+	log := &testBatchReceiver{}
+	sess.EventReceiver = log
+
+	for _, v := range []string{
+		`CREATE TABLE events (id integer, kind text, done integer)`,
+		`INSERT INTO events VALUES (1, 'a', 0), (2, 'b', 0), (3, 'a', 0), (4, 'a', 0), (5, 'b', 0), (6, 'a', 0), (7, 'a', 0)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	b := sess.Update("events").Set("done", 1).Where(Eq("kind", "a")).Batch("id", 2)
+	n, err := b.Exec()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, n)
+	require.EqualValues(t, 6, b.LastKey)
+	require.Len(t, log.chunks, 3)
+	require.Equal(t, map[string]string{"table": "events", "last_key": "3", "rows": "2", "total": "2"}, log.chunks[0])
+	require.Equal(t, map[string]string{"table": "events", "last_key": "6", "rows": "1", "total": "5"}, log.chunks[2])
+
+	// resume a purge after the row with id 4
+	n, err = sess.DeleteFrom("events").Batch("id", 10).Resume(4).Exec()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	var ids []int64
+	_, err = sess.Select("id").From("events").OrderAsc("id").Load(&ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4}, ids)
+
+	_, err = sess.DeleteFrom("events").Limit(1).Batch("id", 10).Exec()
+	require.Equal(t, ErrNotSupported, err)
+}
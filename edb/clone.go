@@ -0,0 +1,77 @@
+package edb
+
+// cloneSlice copies s, so that appending to either slice
+// does not affect the other. A nil slice stays nil.
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+func (r raw) clone() raw {
+	return raw{Query: r.Query, Value: cloneSlice(r.Value)}
+}
+
+// Clone returns a copy of the statement, which can be modified
+// without affecting b. Builders in its clauses are shared.
+func (b *SelectStmt) Clone() *SelectStmt {
+	c := *b
+	c.raw = b.raw.clone()
+	c.Column = cloneSlice(b.Column)
+	c.JoinTable = cloneSlice(b.JoinTable)
+	c.WhereCond = cloneSlice(b.WhereCond)
+	c.Group = cloneSlice(b.Group)
+	c.HavingCond = cloneSlice(b.HavingCond)
+	c.Order = cloneSlice(b.Order)
+	c.Suffixes = cloneSlice(b.Suffixes)
+	c.comments = cloneSlice(b.comments)
+	c.preloads = cloneSlice(b.preloads)
+	return &c
+}
+
+// Clone returns a copy of the statement, which can be modified
+// without affecting b.
+func (b *InsertStmt) Clone() *InsertStmt {
+	c := *b
+	c.raw = b.raw.clone()
+	c.Column = cloneSlice(b.Column)
+	c.Value = cloneSlice(b.Value)
+	for i := range c.Value {
+		c.Value[i] = cloneSlice(c.Value[i])
+	}
+	c.ReturnColumn = cloneSlice(b.ReturnColumn)
+	c.comments = cloneSlice(b.comments)
+	return &c
+}
+
+// Clone returns a copy of the statement, which can be modified
+// without affecting b. Builders in its clauses are shared.
+func (b *UpdateStmt) Clone() *UpdateStmt {
+	c := *b
+	c.raw = b.raw.clone()
+	if b.Value != nil {
+		c.Value = make(map[string]interface{}, len(b.Value))
+		for k, v := range b.Value {
+			c.Value[k] = v
+		}
+	}
+	c.Column = cloneSlice(b.Column)
+	c.JoinTable = cloneSlice(b.JoinTable)
+	c.WhereCond = cloneSlice(b.WhereCond)
+	c.ReturnColumn = cloneSlice(b.ReturnColumn)
+	c.comments = cloneSlice(b.comments)
+	return &c
+}
+
+// Clone returns a copy of the statement, which can be modified
+// without affecting b. Builders in its clauses are shared.
+func (b *DeleteStmt) Clone() *DeleteStmt {
+	c := *b
+	c.raw = b.raw.clone()
+	c.JoinTable = cloneSlice(b.JoinTable)
+	c.WhereCond = cloneSlice(b.WhereCond)
+	c.Order = cloneSlice(b.Order)
+	c.comments = cloneSlice(b.comments)
+	return &c
+}
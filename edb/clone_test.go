@@ -0,0 +1,88 @@
+package edb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	base := Select("*").From("people")
+	// spare capacity would be shared by appends without Clone
+	base.WhereCond = append(make([]Builder, 0, 4), Eq("a", 1))
+	page := base.Clone().Where(Eq("b", 2)).OrderAsc("id").Limit(10)
+	other := base.Clone().Where(Eq("c", 3))
+
+	for _, test := range []struct {
+		builder Builder
+		query   string
+	}{
+		{builder: base, query: "SELECT * FROM people WHERE (`a` = ?)"},
+		{builder: page, query: "SELECT * FROM people WHERE (`a` = ?) AND (`b` = ?) ORDER BY id ASC LIMIT 10"},
+		{builder: other, query: "SELECT * FROM people WHERE (`a` = ?) AND (`c` = ?)"},
+		{builder: InsertInto("t").Columns("a").Values(1).Clone().Values(2), query: "INSERT INTO `t` (`a`) VALUES (?), (?)"},
+		{builder: Update("t").Set("a", 1).Clone().Where(Eq("b", 2)), query: "UPDATE `t` SET `a` = ? WHERE (`b` = ?)"},
+		{builder: DeleteFrom("t").Clone().Where(Eq("b", 2)), query: "DELETE FROM `t` WHERE (`b` = ?)"},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+	}
+
+	insert := InsertInto("t").Columns("a").Values(1)
+	insert.Clone().Value[0][0] = 2
+	require.Equal(t, 1, insert.Value[0][0])
+
+	update := Update("t").Set("a", 1)
+	update.Clone().Set("b", 2)
+	require.Len(t, update.Value, 1)
+}
+
+func TestSQLite3Count(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.Exec(`CREATE TABLE people (id integer, name text)`)
+	require.NoError(t, err)
+	_, err = sess.InsertInto("people").Columns("id", "name").
+		Values(1, "a").Values(2, "b").Values(3, "b").ExecContext(ctx)
+	require.NoError(t, err)
+
+	stmt := sess.Select("*").From("people").Where(Eq("name", "b")).OrderDesc("id").Limit(1)
+	count, err := stmt.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	q := stmt.Clone()
+	q.Column = []interface{}{"id"}
+	var ids []int64
+	_, err = q.LoadContext(ctx, &ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, ids)
+}
+
+func TestCountJoin(t *testing.T) {
+	for _, test := range []struct {
+		builder *SelectStmt
+		query   string
+	}{
+		{
+			builder: Select("*").From("orders o").Join("users", "users.id = o.user_id").Where(Eq("users.active", true)).OrderDesc("o.id").Limit(10),
+			query:   "SELECT COUNT(*) FROM (SELECT 1 FROM orders o JOIN `users` ON users.id = o.user_id WHERE (`users`.`active` = 1)) AS `count_query`",
+		},
+		{
+			builder: Select("o.user_id").Distinct().From("orders o").Join("users", "users.id = o.user_id"),
+			query:   "SELECT COUNT(*) FROM (SELECT DISTINCT o.user_id FROM orders o JOIN `users` ON users.id = o.user_id) AS `count_query`",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.countStmt().Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+}
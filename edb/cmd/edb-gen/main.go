@@ -0,0 +1,66 @@
+// Command edb-gen introspects a MySQL, PostgreSQL, SQLite or MSSQL schema and
+// writes Go model structs with db tags and typed column name constants.
+//
+// Usage:
+//
+//	edb-gen -driver mysql -dsn 'user:pass@tcp(127.0.0.1:3306)/app' -pkg model -out model/tables.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ego-plugin/store/edb"
+	"github.com/ego-plugin/store/edb/gen"
+)
+
+func main() {
+	driver := flag.String("driver", "mysql", "database driver: mysql, postgres, sqlite3 or mssql")
+	dsn := flag.String("dsn", "", "data source name")
+	pkg := flag.String("pkg", "model", "package name of the generated file")
+	out := flag.String("out", "", "output file, stdout if empty")
+	tables := flag.String("tables", "", "comma separated tables to generate, all if empty")
+	flag.Parse()
+
+	if err := run(*driver, *dsn, *pkg, *out, *tables); err != nil {
+		fmt.Fprintln(os.Stderr, "edb-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(driver, dsn, pkg, out, tables string) error {
+	conn, err := edb.Open(driver, dsn, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var name []string
+	if tables != "" {
+		name = strings.Split(tables, ",")
+	}
+	t, err := gen.LoadTables(context.Background(), conn.NewSession(nil), name...)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return gen.Generate(w, pkg, t)
+}
@@ -2,6 +2,8 @@ package edb
 
 import (
 	"reflect"
+
+	"github.com/ego-plugin/store/edb/dialect"
 )
 
 func buildCond(d Dialect, buf Buffer, pred string, cond ...Builder) error {
@@ -40,15 +42,27 @@ func buildCmp(d Dialect, buf Buffer, pred string, column string, value interface
 	buf.WriteString(" ")
 	buf.WriteString(pred)
 	buf.WriteString(" ")
+	switch query := value.(type) {
+	case *SelectStmt, *union:
+		// scalar subquery
+		return buildSubquery(d, buf, query.(Builder))
+	}
 	buf.WriteString(placeholder)
 
 	buf.WriteValue(value)
 	return nil
 }
 
+// isInSlice reports whether value is a slice to be translated to `IN`.
+// Slices implementing driver.Valuer, like Array, are single values.
+func isInSlice(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && !v.Type().Implements(typeValuer)
+}
+
 // Eq is `=`.
 // When value is nil, it will be translated to `IS NULL`.
 // When value is a slice, it will be translated to `IN`.
+// When value is a *SelectStmt, it is compared to the result of the subquery.
 // Otherwise it will be translated to `=`.
 func Eq(column string, value interface{}) Builder {
 	return BuildFunc(func(d Dialect, buf Buffer) error {
@@ -58,7 +72,7 @@ func Eq(column string, value interface{}) Builder {
 			return nil
 		}
 		v := reflect.ValueOf(value)
-		if v.Kind() == reflect.Slice {
+		if isInSlice(v) {
 			if v.Len() == 0 {
 				buf.WriteString(d.EncodeBool(false))
 				return nil
@@ -81,7 +95,7 @@ func Neq(column string, value interface{}) Builder {
 			return nil
 		}
 		v := reflect.ValueOf(value)
-		if v.Kind() == reflect.Slice {
+		if isInSlice(v) {
 			if v.Len() == 0 {
 				buf.WriteString(d.EncodeBool(true))
 				return nil
@@ -148,3 +162,304 @@ func NotLike(column, value string, escape ...string) Builder {
 		return buildLike(d, buf, column, value, true, escape)
 	})
 }
+
+// Between is `BETWEEN lower AND upper`.
+func Between(column string, lower, upper interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildBetween(d, buf, column, lower, upper, false)
+	})
+}
+
+// NotBetween is `NOT BETWEEN lower AND upper`.
+func NotBetween(column string, lower, upper interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildBetween(d, buf, column, lower, upper, true)
+	})
+}
+
+func buildBetween(d Dialect, buf Buffer, column string, lower, upper interface{}, isNot bool) error {
+	buf.WriteString(d.QuoteIdent(column))
+	if isNot {
+		buf.WriteString(" NOT BETWEEN ")
+	} else {
+		buf.WriteString(" BETWEEN ")
+	}
+	buf.WriteString(placeholder)
+	buf.WriteValue(lower)
+	buf.WriteString(" AND ")
+	buf.WriteString(placeholder)
+	buf.WriteValue(upper)
+	return nil
+}
+
+// ILike is case-insensitive `LIKE`, with an optional `ESCAPE` clause.
+// It is `ILIKE` on PostgreSQL, and `LOWER(column) LIKE LOWER(value)` on
+// other dialects.
+func ILike(column, value string, escape ...string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if d == dialect.PostgreSQL {
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(" ILIKE ")
+			buf.WriteString(d.EncodeString(value))
+		} else {
+			buf.WriteString("LOWER(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(") LIKE LOWER(")
+			buf.WriteString(d.EncodeString(value))
+			buf.WriteString(")")
+		}
+		if len(escape) > 0 {
+			buf.WriteString(" ESCAPE ")
+			buf.WriteString(d.EncodeString(escape[0]))
+		}
+		return nil
+	})
+}
+
+// Regexp matches column with a regular expression.
+// It is `~` on PostgreSQL, and `REGEXP` on MySQL and SQLite,
+// where the regexp function must be registered with the driver.
+func Regexp(column, pattern string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		switch d {
+		case dialect.PostgreSQL:
+			return buildCmp(d, buf, "~", column, pattern)
+		case dialect.MySQL, dialect.SQLite3:
+			return buildCmp(d, buf, "REGEXP", column, pattern)
+		}
+		return ErrNotSupported
+	})
+}
+
+// IsDistinctFrom is null-safe `!=`, where NULL is distinct from any value
+// but NULL. It is `NOT (column <=> value)` on MySQL, `IS NOT` on SQLite,
+// and `IS DISTINCT FROM` on other dialects.
+func IsDistinctFrom(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		switch d {
+		case dialect.MySQL:
+			buf.WriteString("NOT (")
+			err := buildCmp(d, buf, "<=>", column, value)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(")")
+			return nil
+		case dialect.SQLite3:
+			return buildCmp(d, buf, "IS NOT", column, value)
+		}
+		return buildCmp(d, buf, "IS DISTINCT FROM", column, value)
+	})
+}
+
+// IsNotDistinctFrom is null-safe `=`, where NULL equals NULL.
+// It is `<=>` on MySQL, `IS` on SQLite, and `IS NOT DISTINCT FROM` on
+// other dialects.
+func IsNotDistinctFrom(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		switch d {
+		case dialect.MySQL:
+			return buildCmp(d, buf, "<=>", column, value)
+		case dialect.SQLite3:
+			return buildCmp(d, buf, "IS", column, value)
+		}
+		return buildCmp(d, buf, "IS NOT DISTINCT FROM", column, value)
+	})
+}
+
+// Match is a full-text search of query in columns.
+// It is `MATCH (columns) AGAINST (query)` on MySQL,
+// `to_tsvector(concat_ws(' ', columns)) @@ plainto_tsquery(query)` on
+// PostgreSQL, and `FREETEXT((columns), query)` on MSSQL.
+func Match(column []string, query string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if len(column) == 0 {
+			return ErrColumnNotSpecified
+		}
+		writeColumns := func() {
+			for i, col := range column {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(d.QuoteIdent(col))
+			}
+		}
+		switch d {
+		case dialect.MySQL:
+			buf.WriteString("MATCH (")
+			writeColumns()
+			buf.WriteString(") AGAINST (")
+		case dialect.PostgreSQL:
+			if len(column) == 1 {
+				buf.WriteString("to_tsvector(")
+				writeColumns()
+			} else {
+				buf.WriteString("to_tsvector(concat_ws(' ', ")
+				writeColumns()
+				buf.WriteString(")")
+			}
+			buf.WriteString(") @@ plainto_tsquery(")
+		case dialect.MSSQL:
+			buf.WriteString("FREETEXT((")
+			writeColumns()
+			buf.WriteString("), ")
+		default:
+			return ErrNotSupported
+		}
+		buf.WriteString(placeholder)
+		buf.WriteValue(query)
+		buf.WriteString(")")
+		return nil
+	})
+}
+
+// buildSubquery writes query in parentheses.
+func buildSubquery(d Dialect, buf Buffer, query Builder) error {
+	buf.WriteString("(")
+	err := query.Build(d, buf)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+// Exists is `EXISTS (query)`.
+func Exists(query Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString("EXISTS ")
+		return buildSubquery(d, buf, query)
+	})
+}
+
+// NotExists is `NOT EXISTS (query)`.
+func NotExists(query Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString("NOT EXISTS ")
+		return buildSubquery(d, buf, query)
+	})
+}
+
+// InSelect is `column IN (query)`.
+func InSelect(column string, query Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(" IN ")
+		return buildSubquery(d, buf, query)
+	})
+}
+
+// NotInSelect is `column NOT IN (query)`.
+func NotInSelect(column string, query Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(" NOT IN ")
+		return buildSubquery(d, buf, query)
+	})
+}
+
+func buildTuple(d Dialect, buf Buffer, column []string, value interface{}, isNot bool) error {
+	if len(column) == 0 {
+		return ErrColumnNotSpecified
+	}
+	rows, isRows := value.([][]interface{})
+	if isRows {
+		if len(rows) == 0 {
+			buf.WriteString(d.EncodeBool(isNot))
+			return nil
+		}
+		for _, row := range rows {
+			if len(row) != len(column) {
+				return ErrInvalidTuple
+			}
+		}
+	}
+
+	if d == dialect.MSSQL {
+		// no row values, so rows are expanded to
+		// `(a = 1 AND b = 2) OR (a = 3 AND b = 4)`
+		if !isRows {
+			return ErrNotSupported
+		}
+		cond := make([]Builder, len(rows))
+		for i, row := range rows {
+			eq := make([]Builder, len(column))
+			for j := range column {
+				eq[j] = Eq(column[j], row[j])
+			}
+			cond[i] = And(eq...)
+		}
+		if isNot {
+			buf.WriteString("NOT (")
+		}
+		err := Or(cond...).Build(d, buf)
+		if err != nil {
+			return err
+		}
+		if isNot {
+			buf.WriteString(")")
+		}
+		return nil
+	}
+
+	buf.WriteString("(")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	if isNot {
+		buf.WriteString(") NOT IN ")
+	} else {
+		buf.WriteString(") IN ")
+	}
+	if !isRows {
+		query, ok := value.(Builder)
+		if !ok {
+			return ErrNotSupported
+		}
+		return buildSubquery(d, buf, query)
+	}
+	buf.WriteString("(")
+	if d == dialect.SQLite3 {
+		// SQLite only compares row values to a subquery
+		buf.WriteString("VALUES ")
+	}
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("(")
+		for j, v := range row {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(placeholder)
+			buf.WriteValue(v)
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+// InTuple is a row value comparison `(a, b) IN ((1, 2), (3, 4))`.
+// value can be [][]interface{} with a value for each column in a row,
+// or a Builder like a subquery selecting the columns.
+//
+// On MSSQL, which has no row values, rows are expanded to
+// `(a = 1 AND b = 2) OR (a = 3 AND b = 4)`, and a subquery is not supported.
+func InTuple(column []string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildTuple(d, buf, column, value, false)
+	})
+}
+
+// NotInTuple is a row value comparison `(a, b) NOT IN ((1, 2), (3, 4))`.
+// It is expanded like InTuple on MSSQL.
+func NotInTuple(column []string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildTuple(d, buf, column, value, true)
+	})
+}
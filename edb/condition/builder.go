@@ -20,7 +20,7 @@ func NewBuilders() *Builders {
 }
 
 // ScanSelectBuilder 扫描Builder写入查询SQL语句
-func (b *Builders) ScanSelectBuilder(stmt edb.SelectBuilder) {
+func (b *Builders) ScanSelectBuilder(stmt *edb.SelectBuilder) {
 	for _, v := range b.Value {
 		switch reflect.TypeOf(v) {
 		case whereType:
@@ -139,4 +139,53 @@ func NotLike(column, value string, ok bool, escape ...string) Builder {
 	return WhereFunc(func() (edb.Builder, bool) {
 		return edb.NotLike(column, value, escape...), ok
 	})
-}
\ No newline at end of file
+}
+
+// Between is `BETWEEN lower AND upper`.
+func Between(column string, lower, upper interface{}, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.Between(column, lower, upper), ok
+	})
+}
+
+// NotBetween is `NOT BETWEEN lower AND upper`.
+func NotBetween(column string, lower, upper interface{}, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.NotBetween(column, lower, upper), ok
+	})
+}
+
+// ILike is case-insensitive `LIKE`, with an optional `ESCAPE` clause
+func ILike(column, value string, ok bool, escape ...string) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.ILike(column, value, escape...), ok
+	})
+}
+
+// Regexp matches column with a regular expression.
+func Regexp(column, pattern string, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.Regexp(column, pattern), ok
+	})
+}
+
+// IsDistinctFrom is null-safe `!=`.
+func IsDistinctFrom(column string, value interface{}, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.IsDistinctFrom(column, value), ok
+	})
+}
+
+// IsNotDistinctFrom is null-safe `=`.
+func IsNotDistinctFrom(column string, value interface{}, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.IsNotDistinctFrom(column, value), ok
+	})
+}
+
+// Match is a full-text search of query in columns.
+func Match(column []string, query string, ok bool) Builder {
+	return WhereFunc(func() (edb.Builder, bool) {
+		return edb.Match(column, query), ok
+	})
+}
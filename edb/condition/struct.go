@@ -0,0 +1,133 @@
+package condition
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ego-plugin/store/edb"
+)
+
+// ErrInvalidFilter is returned by FromStruct for an invalid filter struct.
+var ErrInvalidFilter = errors.New("condition: invalid filter")
+
+// FromStruct builds WHERE conditions from the fields of a filter struct,
+// tagged like `cond:"status,eq"` with the column and the operator.
+//
+// The operators are eq, neq, gt, gte, lt, lte, like, notlike, ilike and
+// regexp. If the operator is omitted, it is eq, and if the column is
+// omitted, it is the field name mapped by edb.NameMapping. Fields tagged
+// with `cond:"-"` are ignored, and so are fields without a cond tag
+// except embedded structs, whose fields are read as well.
+//
+// Zero fields, nil pointers and empty slices, even behind a pointer, are
+// skipped, so a pointer is used to filter by a zero value like false.
+// A slice with eq or neq is translated to `IN` or `NOT IN`. The value of
+// like, notlike, ilike and regexp is used as the pattern as is.
+//
+// It returns ErrInvalidFilter if filter is not a struct or a pointer to
+// a struct, or if an operator is unknown.
+func FromStruct(filter interface{}) (*Builders, error) {
+	v := reflect.Indirect(reflect.ValueOf(filter))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrInvalidFilter, filter)
+	}
+	b := NewBuilders()
+	err := fromStruct(b, v)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func fromStruct(b *Builders, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("cond")
+		if tag == "-" {
+			continue
+		}
+		fieldValue := v.Field(i)
+		if !hasTag {
+			if field.Anonymous {
+				fieldValue = reflect.Indirect(fieldValue)
+				if fieldValue.Kind() == reflect.Struct {
+					err := fromStruct(b, fieldValue)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		column, op, _ := strings.Cut(tag, ",")
+		column, op = strings.TrimSpace(column), strings.TrimSpace(op)
+		if column == "" {
+			column = edb.NameMapping(field.Name)
+		}
+		if op == "" {
+			op = "eq"
+		}
+		build, ok := opBuilders[op]
+		if !ok {
+			return fmt.Errorf("%w: unknown operator %q of field %s", ErrInvalidFilter, op, field.Name)
+		}
+
+		fieldValue, ok = filterValue(fieldValue)
+		if !ok {
+			continue
+		}
+		cond := build(column, fieldValue.Interface())
+		b.Append(WhereFunc(func() (edb.Builder, bool) {
+			return cond, true
+		}))
+	}
+	return nil
+}
+
+// filterValue returns the value of a filter field, or false if it is
+// zero, nil or an empty slice.
+func filterValue(v reflect.Value) (reflect.Value, bool) {
+	if v.IsZero() {
+		return v, false
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Len() == 0 {
+		return v, false
+	}
+	return v, true
+}
+
+// opBuilders are the operators of cond tags.
+var opBuilders = map[string]func(column string, value interface{}) edb.Builder{
+	"eq":  edb.Eq,
+	"neq": edb.Neq,
+	"gt":  edb.Gt,
+	"gte": edb.Gte,
+	"lt":  edb.Lt,
+	"lte": edb.Lte,
+	"like": func(column string, value interface{}) edb.Builder {
+		return edb.Like(column, fmt.Sprint(value))
+	},
+	"notlike": func(column string, value interface{}) edb.Builder {
+		return edb.NotLike(column, fmt.Sprint(value))
+	},
+	"ilike": func(column string, value interface{}) edb.Builder {
+		return edb.ILike(column, fmt.Sprint(value))
+	},
+	"regexp": func(column string, value interface{}) edb.Builder {
+		return edb.Regexp(column, fmt.Sprint(value))
+	},
+}
@@ -0,0 +1,82 @@
+package condition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ego-plugin/store/edb"
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+type testPage struct {
+	Page uint64 `cond:"-"`
+}
+
+type testFilter struct {
+	testPage
+	Status    string    `cond:"status,eq"`
+	Name      string    `cond:"name,like"`
+	Active    *bool     `cond:"active"`
+	Kind      []string  `cond:",neq"`
+	CreatedAt time.Time `cond:"created_at,gte"`
+	Age       int       `cond:"age,lt"`
+	IDs       *[]int64  `cond:"id"`
+	Note      string
+}
+
+func TestFromStruct(t *testing.T) {
+	active := false
+	for _, test := range []struct {
+		filter interface{}
+		query  string
+	}{
+		{
+			filter: testFilter{
+				testPage:  testPage{Page: 2},
+				Status:    "open",
+				Name:      "%go%",
+				Active:    &active,
+				Kind:      []string{"a", "b"},
+				CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Note:      "ignored",
+			},
+			query: "SELECT * FROM t WHERE (`status` = 'open') AND (`name` LIKE '%go%') AND (`active` = 0) AND (`kind` NOT IN ('a','b')) AND (`created_at` >= '2024-01-02 00:00:00.000000')",
+		},
+		{
+			filter: &testFilter{Age: 18, Kind: []string{}},
+			query:  "SELECT * FROM t WHERE (`age` < 18)",
+		},
+		{
+			filter: testFilter{},
+			query:  "SELECT * FROM t",
+		},
+		{
+			// a pointer to an empty slice is no filter, like a nil pointer
+			filter: testFilter{IDs: &[]int64{}},
+			query:  "SELECT * FROM t",
+		},
+		{
+			filter: testFilter{IDs: &[]int64{1, 2}},
+			query:  "SELECT * FROM t WHERE (`id` IN (1,2))",
+		},
+	} {
+		stmt := edb.Select("*").From("t")
+		b, err := FromStruct(test.filter)
+		require.NoError(t, err)
+		b.ScanSelectBuilder(stmt)
+		buf := edb.NewBuffer()
+		err = stmt.Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		query, err := edb.InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	_, err := FromStruct(struct {
+		A int `cond:"a,unknown"`
+	}{A: 1})
+	require.ErrorIs(t, err, ErrInvalidFilter)
+	_, err = FromStruct(1)
+	require.ErrorIs(t, err, ErrInvalidFilter)
+}
@@ -101,3 +101,229 @@ func TestCondition(t *testing.T) {
 		require.Equal(t, test.value, buf.Value())
 	}
 }
+
+func TestSubqueryCondition(t *testing.T) {
+	sub := Select("user_id").From("orders").Where(Gt("total", 100))
+	for _, test := range []struct {
+		cond  Builder
+		d     Dialect
+		query string
+	}{
+		{
+			cond:  Exists(Select("1").From("orders").Where("orders.user_id = users.id")),
+			d:     dialect.MySQL,
+			query: "EXISTS (SELECT 1 FROM orders WHERE (orders.user_id = users.id))",
+		},
+		{
+			cond:  NotExists(Select("1").From("orders").Where("orders.user_id = users.id")),
+			d:     dialect.MySQL,
+			query: "NOT EXISTS (SELECT 1 FROM orders WHERE (orders.user_id = users.id))",
+		},
+		{
+			cond:  InSelect("id", sub),
+			d:     dialect.PostgreSQL,
+			query: `"id" IN (SELECT user_id FROM orders WHERE ("total" > 100))`,
+		},
+		{
+			cond:  NotInSelect("id", Expr("SELECT user_id FROM banned")),
+			d:     dialect.PostgreSQL,
+			query: `"id" NOT IN (SELECT user_id FROM banned)`,
+		},
+		{
+			cond:  Eq("total", Select("MAX(total)").From("orders")),
+			d:     dialect.MySQL,
+			query: "`total` = (SELECT MAX(total) FROM orders)",
+		},
+		{
+			cond:  InTuple([]string{"a", "b"}, [][]interface{}{{1, "x"}, {2, "y"}}),
+			d:     dialect.MySQL,
+			query: "(`a`, `b`) IN ((1, 'x'), (2, 'y'))",
+		},
+		{
+			cond:  NotInTuple([]string{"a", "b"}, Select("a", "b").From("t")),
+			d:     dialect.PostgreSQL,
+			query: `("a", "b") NOT IN (SELECT a, b FROM t)`,
+		},
+		{
+			cond:  InTuple([]string{"a", "b"}, [][]interface{}{{1, "x"}, {2, "y"}}),
+			d:     dialect.MSSQL,
+			query: `(("a" = 1) AND ("b" = 'x')) OR (("a" = 2) AND ("b" = 'y'))`,
+		},
+		{
+			cond:  NotInTuple([]string{"a", "b"}, [][]interface{}{{1, "x"}}),
+			d:     dialect.MSSQL,
+			query: `NOT ((("a" = 1) AND ("b" = 'x')))`,
+		},
+		{
+			cond:  InTuple([]string{"a", "b"}, [][]interface{}{}),
+			d:     dialect.MySQL,
+			query: "0",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.cond.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	err := InTuple([]string{"a", "b"}, [][]interface{}{{1}}).Build(dialect.MySQL, NewBuffer())
+	require.Equal(t, ErrInvalidTuple, err)
+	err = InTuple([]string{"a", "b"}, sub).Build(dialect.MSSQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSQLite3SubqueryCondition(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE users (id integer, org integer)`,
+		`CREATE TABLE orders (id integer, user_id integer)`,
+		`INSERT INTO users VALUES (1, 1), (2, 1), (3, 2)`,
+		`INSERT INTO orders VALUES (10, 1), (11, 3)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	var ids []int64
+	_, err := sess.Select("id").From("users").
+		Where(Exists(Select("1").From("orders").Where("orders.user_id = users.id"))).
+		OrderAsc("id").Load(&ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, ids)
+
+	ids = nil
+	_, err = sess.Select("id").From("users").
+		Where(NotInSelect("id", Select("user_id").From("orders"))).
+		Load(&ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, ids)
+
+	ids = nil
+	_, err = sess.Select("id").From("users").
+		Where(InTuple([]string{"id", "org"}, [][]interface{}{{1, 1}, {3, 1}, {3, 2}})).
+		OrderAsc("id").Load(&ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, ids)
+}
+
+func TestDialectCondition(t *testing.T) {
+	for _, test := range []struct {
+		cond  Builder
+		d     Dialect
+		query string
+	}{
+		{
+			cond:  Between("age", 18, 65),
+			d:     dialect.MySQL,
+			query: "`age` BETWEEN 18 AND 65",
+		},
+		{
+			cond:  NotBetween("age", 18, 65),
+			d:     dialect.PostgreSQL,
+			query: `"age" NOT BETWEEN 18 AND 65`,
+		},
+		{
+			cond:  ILike("name", "%Go%"),
+			d:     dialect.PostgreSQL,
+			query: `"name" ILIKE '%Go%'`,
+		},
+		{
+			cond:  ILike("name", "%Go#%%", "#"),
+			d:     dialect.MySQL,
+			query: "LOWER(`name`) LIKE LOWER('%Go#%%') ESCAPE '#'",
+		},
+		{
+			cond:  Regexp("name", "^go"),
+			d:     dialect.PostgreSQL,
+			query: `"name" ~ '^go'`,
+		},
+		{
+			cond:  Regexp("name", "^go"),
+			d:     dialect.MySQL,
+			query: "`name` REGEXP '^go'",
+		},
+		{
+			cond:  IsDistinctFrom("a", nil),
+			d:     dialect.MySQL,
+			query: "NOT (`a` <=> NULL)",
+		},
+		{
+			cond:  IsDistinctFrom("a", 1),
+			d:     dialect.PostgreSQL,
+			query: `"a" IS DISTINCT FROM 1`,
+		},
+		{
+			cond:  IsNotDistinctFrom("a", 1),
+			d:     dialect.MySQL,
+			query: "`a` <=> 1",
+		},
+		{
+			cond:  IsNotDistinctFrom("a", 1),
+			d:     dialect.SQLite3,
+			query: `"a" IS 1`,
+		},
+		{
+			cond:  Match([]string{"title", "body"}, "go sql"),
+			d:     dialect.MySQL,
+			query: "MATCH (`title`, `body`) AGAINST ('go sql')",
+		},
+		{
+			cond:  Match([]string{"title", "body"}, "go sql"),
+			d:     dialect.PostgreSQL,
+			query: `to_tsvector(concat_ws(' ', "title", "body")) @@ plainto_tsquery('go sql')`,
+		},
+		{
+			cond:  Match([]string{"title"}, "go"),
+			d:     dialect.PostgreSQL,
+			query: `to_tsvector("title") @@ plainto_tsquery('go')`,
+		},
+		{
+			cond:  Match([]string{"title"}, "go"),
+			d:     dialect.MSSQL,
+			query: `FREETEXT(("title"), 'go')`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.cond.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	err := Regexp("name", "^go").Build(dialect.MSSQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+	err = Match([]string{"title"}, "go").Build(dialect.SQLite3, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSQLite3DialectCondition(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE people (id integer, name text, age integer)`,
+		`INSERT INTO people VALUES (1, 'Alice', 30), (2, 'bob', NULL), (3, 'ALFRED', 70)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	for _, test := range []struct {
+		cond Builder
+		want []int64
+	}{
+		{cond: Between("age", 18, 65), want: []int64{1}},
+		{cond: NotBetween("age", 18, 65), want: []int64{3}},
+		{cond: ILike("name", "al%"), want: []int64{1, 3}},
+		{cond: IsDistinctFrom("age", 30), want: []int64{2, 3}},
+		{cond: IsNotDistinctFrom("age", nil), want: []int64{2}},
+	} {
+		var ids []int64
+		_, err := sess.Select("id").From("people").Where(test.cond).OrderAsc("id").Load(&ids)
+		require.NoError(t, err)
+		require.Equal(t, test.want, ids)
+	}
+}
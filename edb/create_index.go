@@ -0,0 +1,123 @@
+package edb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// CreateIndexStmt builds `CREATE [UNIQUE] INDEX ...`.
+type CreateIndexStmt struct {
+	runner
+	EventReceiver
+	Dialect
+
+	Name        string
+	Table       string
+	Column      []string
+	IsUnique    bool
+	CheckExists bool
+	comments    Comments
+}
+
+type CreateIndexBuilder = CreateIndexStmt
+
+func (b *CreateIndexStmt) Build(d Dialect, buf Buffer) error {
+	if b.Name == "" {
+		return ErrIndexNotSpecified
+	}
+
+	if b.Table == "" {
+		return ErrTableNotSpecified
+	}
+
+	if len(b.Column) == 0 {
+		return ErrColumnNotSpecified
+	}
+
+	if b.CheckExists && d == dialect.MySQL {
+		// MySQL has no CREATE INDEX IF NOT EXISTS
+		return ErrNotSupported
+	}
+
+	err := b.comments.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
+	if b.CheckExists && d == dialect.MSSQL {
+		buf.WriteString("IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = ")
+		buf.WriteString(d.EncodeString(b.Name))
+		buf.WriteString(" AND object_id = OBJECT_ID(")
+		buf.WriteString(d.EncodeString(b.Table))
+		buf.WriteString(")) ")
+	}
+
+	buf.WriteString("CREATE ")
+	if b.IsUnique {
+		buf.WriteString("UNIQUE ")
+	}
+	buf.WriteString("INDEX ")
+	if b.CheckExists && d != dialect.MSSQL {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	buf.WriteString(d.QuoteIdent(b.Name))
+	buf.WriteString(" ON ")
+	buf.WriteString(d.QuoteIdent(b.Table))
+	buf.WriteString(" ")
+	buildIdentList(d, buf, b.Column)
+	return nil
+}
+
+// CreateIndex creates a CreateIndexStmt.
+func CreateIndex(name, table string, column ...string) *CreateIndexStmt {
+	return &CreateIndexStmt{
+		Name:   name,
+		Table:  table,
+		Column: column,
+	}
+}
+
+// CreateIndex creates a CreateIndexStmt.
+func (sess *Session) CreateIndex(name, table string, column ...string) *CreateIndexStmt {
+	b := CreateIndex(name, table, column...)
+	b.runner = sess
+	b.EventReceiver = sess.EventReceiver
+	b.Dialect = sess.Dialect
+	return b
+}
+
+// CreateIndex creates a CreateIndexStmt.
+func (tx *Tx) CreateIndex(name, table string, column ...string) *CreateIndexStmt {
+	b := CreateIndex(name, table, column...)
+	b.runner = tx
+	b.EventReceiver = tx.EventReceiver
+	b.Dialect = tx.Dialect
+	return b
+}
+
+// Unique creates a unique index.
+func (b *CreateIndexStmt) Unique() *CreateIndexStmt {
+	b.IsUnique = true
+	return b
+}
+
+// IfNotExists adds `IF NOT EXISTS`. It is not supported by MySQL.
+func (b *CreateIndexStmt) IfNotExists() *CreateIndexStmt {
+	b.CheckExists = true
+	return b
+}
+
+func (b *CreateIndexStmt) Comment(comment string) *CreateIndexStmt {
+	b.comments = b.comments.Append(comment)
+	return b
+}
+
+func (b *CreateIndexStmt) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+func (b *CreateIndexStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	return exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
+}
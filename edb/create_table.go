@@ -0,0 +1,171 @@
+package edb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// CreateTableStmt builds `CREATE TABLE ...`.
+type CreateTableStmt struct {
+	runner
+	EventReceiver
+	Dialect
+
+	Table       string
+	CheckExists bool
+	Column      []*ColumnDef
+	PrimaryKeys []string
+	UniqueKeys  [][]string
+	ForeignKeys []*ForeignKeyDef
+	comments    Comments
+}
+
+type CreateTableBuilder = CreateTableStmt
+
+func (b *CreateTableStmt) Build(d Dialect, buf Buffer) error {
+	if b.Table == "" {
+		return ErrTableNotSpecified
+	}
+
+	if len(b.Column) == 0 {
+		return ErrColumnNotSpecified
+	}
+
+	err := b.comments.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
+	if b.CheckExists {
+		if d == dialect.MSSQL {
+			buf.WriteString("IF OBJECT_ID(")
+			buf.WriteString(d.EncodeString(b.Table))
+			buf.WriteString(", 'U') IS NULL CREATE TABLE ")
+		} else {
+			buf.WriteString("CREATE TABLE IF NOT EXISTS ")
+		}
+	} else {
+		buf.WriteString("CREATE TABLE ")
+	}
+	buf.WriteString(d.QuoteIdent(b.Table))
+	buf.WriteString(" (")
+
+	for i, col := range b.Column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		err := col.Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.PrimaryKeys) > 0 {
+		buf.WriteString(", PRIMARY KEY ")
+		buildIdentList(d, buf, b.PrimaryKeys)
+	}
+
+	for _, unique := range b.UniqueKeys {
+		buf.WriteString(", UNIQUE ")
+		buildIdentList(d, buf, unique)
+	}
+
+	// MySQL parses but ignores REFERENCES in a column definition,
+	// so foreign keys are always written as table constraints.
+	for _, col := range b.Column {
+		if col.RefTable == "" {
+			continue
+		}
+		buf.WriteString(", ")
+		fk := ForeignKeyDef{
+			Column:    []string{col.Name},
+			RefTable:  col.RefTable,
+			RefColumn: []string{col.RefColumn},
+		}
+		fk.Build(d, buf)
+	}
+	for _, fk := range b.ForeignKeys {
+		buf.WriteString(", ")
+		err := fk.Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString(")")
+	return nil
+}
+
+// CreateTable creates a CreateTableStmt.
+func CreateTable(table string) *CreateTableStmt {
+	return &CreateTableStmt{
+		Table: table,
+	}
+}
+
+// CreateTable creates a CreateTableStmt.
+func (sess *Session) CreateTable(table string) *CreateTableStmt {
+	b := CreateTable(table)
+	b.runner = sess
+	b.EventReceiver = sess.EventReceiver
+	b.Dialect = sess.Dialect
+	return b
+}
+
+// CreateTable creates a CreateTableStmt.
+func (tx *Tx) CreateTable(table string) *CreateTableStmt {
+	b := CreateTable(table)
+	b.runner = tx
+	b.EventReceiver = tx.EventReceiver
+	b.Dialect = tx.Dialect
+	return b
+}
+
+// IfNotExists adds `IF NOT EXISTS`.
+func (b *CreateTableStmt) IfNotExists() *CreateTableStmt {
+	b.CheckExists = true
+	return b
+}
+
+// Columns adds column definitions.
+func (b *CreateTableStmt) Columns(column ...*ColumnDef) *CreateTableStmt {
+	b.Column = append(b.Column, column...)
+	return b
+}
+
+// PrimaryKey specifies a (composite) primary key.
+func (b *CreateTableStmt) PrimaryKey(column ...string) *CreateTableStmt {
+	b.PrimaryKeys = column
+	return b
+}
+
+// Unique adds a (composite) unique constraint.
+func (b *CreateTableStmt) Unique(column ...string) *CreateTableStmt {
+	b.UniqueKeys = append(b.UniqueKeys, column)
+	return b
+}
+
+// ForeignKey adds a (composite) foreign key constraint.
+func (b *CreateTableStmt) ForeignKey(column []string, refTable string, refColumn []string) *CreateTableStmt {
+	b.ForeignKeys = append(b.ForeignKeys, &ForeignKeyDef{
+		Column:    column,
+		RefTable:  refTable,
+		RefColumn: refColumn,
+	})
+	return b
+}
+
+func (b *CreateTableStmt) Comment(comment string) *CreateTableStmt {
+	b.comments = b.comments.Append(comment)
+	return b
+}
+
+func (b *CreateTableStmt) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+func (b *CreateTableStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	return exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
+}
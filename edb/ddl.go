@@ -0,0 +1,248 @@
+package edb
+
+import (
+	"strconv"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// ColumnType is a portable column type.
+// It is rendered to the closest native type of each Dialect.
+type ColumnType struct {
+	name  string
+	size  int
+	scale int
+}
+
+// portable column types
+var (
+	TypeSmallInt  = ColumnType{name: "smallint"}
+	TypeInt       = ColumnType{name: "integer"}
+	TypeBigInt    = ColumnType{name: "bigint"}
+	TypeBool      = ColumnType{name: "boolean"}
+	TypeFloat     = ColumnType{name: "double"}
+	TypeText      = ColumnType{name: "text"}
+	TypeBlob      = ColumnType{name: "blob"}
+	TypeDate      = ColumnType{name: "date"}
+	TypeTimestamp = ColumnType{name: "timestamp"}
+)
+
+// TypeVarchar is `VARCHAR(size)`.
+func TypeVarchar(size int) ColumnType {
+	return ColumnType{name: "varchar", size: size}
+}
+
+// TypeDecimal is `DECIMAL(precision, scale)`.
+func TypeDecimal(precision, scale int) ColumnType {
+	return ColumnType{name: "decimal", size: precision, scale: scale}
+}
+
+// RawType is a native type written as is, like `jsonb` or `enum('a','b')`.
+func RawType(typ string) ColumnType {
+	return ColumnType{name: typ, size: -1}
+}
+
+func (t ColumnType) build(d Dialect, autoIncrement bool) string {
+	if t.size < 0 {
+		return t.name
+	}
+	switch t.name {
+	case "smallint", "integer", "bigint":
+		if !autoIncrement {
+			return t.name
+		}
+		switch d {
+		case dialect.PostgreSQL:
+			switch t.name {
+			case "smallint":
+				return "smallserial"
+			case "bigint":
+				return "bigserial"
+			}
+			return "serial"
+		case dialect.SQLite3:
+			// only INTEGER PRIMARY KEY is an alias of rowid
+			return "integer"
+		}
+		return t.name
+	case "boolean":
+		if d == dialect.MSSQL {
+			return "bit"
+		}
+		return t.name
+	case "double":
+		switch d {
+		case dialect.PostgreSQL:
+			return "double precision"
+		case dialect.SQLite3:
+			return "real"
+		case dialect.MSSQL:
+			return "float"
+		}
+		return t.name
+	case "text":
+		if d == dialect.MSSQL {
+			return "nvarchar(max)"
+		}
+		return t.name
+	case "blob":
+		switch d {
+		case dialect.MySQL:
+			return "longblob"
+		case dialect.PostgreSQL:
+			return "bytea"
+		case dialect.MSSQL:
+			return "varbinary(max)"
+		}
+		return t.name
+	case "timestamp":
+		switch d {
+		case dialect.MySQL:
+			return "datetime(6)"
+		case dialect.MSSQL:
+			return "datetime2"
+		}
+		return t.name
+	case "varchar":
+		if d == dialect.MSSQL {
+			return "nvarchar(" + strconv.Itoa(t.size) + ")"
+		}
+		return "varchar(" + strconv.Itoa(t.size) + ")"
+	case "decimal":
+		return t.name + "(" + strconv.Itoa(t.size) + "," + strconv.Itoa(t.scale) + ")"
+	}
+	return t.name
+}
+
+// ColumnDef defines a column for CreateTable and AlterTable.
+type ColumnDef struct {
+	Name            string
+	Type            ColumnType
+	IsNotNull       bool
+	DefaultValue    interface{}
+	IsPrimaryKey    bool
+	IsAutoIncrement bool
+	IsUnique        bool
+	RefTable        string
+	RefColumn       string
+}
+
+// Col creates a ColumnDef.
+func Col(name string, typ ColumnType) *ColumnDef {
+	return &ColumnDef{
+		Name: name,
+		Type: typ,
+	}
+}
+
+// NotNull adds `NOT NULL`.
+func (c *ColumnDef) NotNull() *ColumnDef {
+	c.IsNotNull = true
+	return c
+}
+
+// Default sets the default value.
+// value can be Builder like Expr("CURRENT_TIMESTAMP"), or a plain value.
+// A value which can only be sent as a query parameter, like []byte,
+// is not supported, because DDL has no parameters.
+func (c *ColumnDef) Default(value interface{}) *ColumnDef {
+	c.DefaultValue = value
+	return c
+}
+
+// PrimaryKey makes the column the primary key.
+// Use CreateTableStmt.PrimaryKey for composite keys.
+func (c *ColumnDef) PrimaryKey() *ColumnDef {
+	c.IsPrimaryKey = true
+	return c
+}
+
+// AutoIncrement makes the column generated by the database.
+// It renders AUTO_INCREMENT on MySQL, serial on PostgreSQL,
+// AUTOINCREMENT on SQLite and IDENTITY on MSSQL.
+func (c *ColumnDef) AutoIncrement() *ColumnDef {
+	c.IsAutoIncrement = true
+	return c
+}
+
+// Unique adds `UNIQUE`.
+func (c *ColumnDef) Unique() *ColumnDef {
+	c.IsUnique = true
+	return c
+}
+
+// References adds a foreign key to column of table.
+func (c *ColumnDef) References(table, column string) *ColumnDef {
+	c.RefTable = table
+	c.RefColumn = column
+	return c
+}
+
+// Build writes the column definition without foreign key.
+func (c *ColumnDef) Build(d Dialect, buf Buffer) error {
+	if c.Name == "" {
+		return ErrColumnNotSpecified
+	}
+	buf.WriteString(d.QuoteIdent(c.Name))
+	buf.WriteString(" ")
+	buf.WriteString(c.Type.build(d, c.IsAutoIncrement))
+
+	if c.IsAutoIncrement && d == dialect.SQLite3 {
+		// AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY
+		buf.WriteString(" PRIMARY KEY AUTOINCREMENT")
+		return nil
+	}
+	if c.IsAutoIncrement && d == dialect.MSSQL {
+		buf.WriteString(" IDENTITY")
+	}
+	if c.IsNotNull {
+		buf.WriteString(" NOT NULL")
+	}
+	if c.DefaultValue != nil {
+		if isBindValue(c.DefaultValue) {
+			return ErrNotSupported
+		}
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(c.DefaultValue)
+	}
+	if c.IsAutoIncrement && d == dialect.MySQL {
+		buf.WriteString(" AUTO_INCREMENT")
+	}
+	if c.IsPrimaryKey {
+		buf.WriteString(" PRIMARY KEY")
+	}
+	if c.IsUnique {
+		buf.WriteString(" UNIQUE")
+	}
+	return nil
+}
+
+func buildIdentList(d Dialect, buf Buffer, column []string) {
+	buf.WriteString("(")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(")")
+}
+
+// ForeignKeyDef defines a table foreign key.
+type ForeignKeyDef struct {
+	Column    []string
+	RefTable  string
+	RefColumn []string
+}
+
+// Build writes `FOREIGN KEY (...) REFERENCES table (...)`.
+func (fk *ForeignKeyDef) Build(d Dialect, buf Buffer) error {
+	buf.WriteString("FOREIGN KEY ")
+	buildIdentList(d, buf, fk.Column)
+	buf.WriteString(" REFERENCES ")
+	buf.WriteString(d.QuoteIdent(fk.RefTable))
+	buf.WriteString(" ")
+	buildIdentList(d, buf, fk.RefColumn)
+	return nil
+}
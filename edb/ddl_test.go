@@ -0,0 +1,171 @@
+package edb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableStmt(t *testing.T) {
+	builder := CreateTable("people").IfNotExists().Columns(
+		Col("id", TypeBigInt).AutoIncrement().PrimaryKey(),
+		Col("name", TypeVarchar(255)).NotNull().Default("anonymous"),
+		Col("active", TypeBool).NotNull().Default(true),
+		Col("group_id", TypeInt).References("groups", "id"),
+		Col("created_at", TypeTimestamp).Default(Expr("CURRENT_TIMESTAMP")),
+	).Unique("name", "group_id")
+
+	for _, test := range []struct {
+		d    Dialect
+		want string
+	}{
+		{
+			d:    dialect.MySQL,
+			want: "CREATE TABLE IF NOT EXISTS `people` (`id` bigint AUTO_INCREMENT PRIMARY KEY, `name` varchar(255) NOT NULL DEFAULT 'anonymous', `active` boolean NOT NULL DEFAULT 1, `group_id` integer, `created_at` datetime(6) DEFAULT CURRENT_TIMESTAMP, UNIQUE (`name`, `group_id`), FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`))",
+		},
+		{
+			d:    dialect.PostgreSQL,
+			want: `CREATE TABLE IF NOT EXISTS "people" ("id" bigserial PRIMARY KEY, "name" varchar(255) NOT NULL DEFAULT 'anonymous', "active" boolean NOT NULL DEFAULT TRUE, "group_id" integer, "created_at" timestamp DEFAULT CURRENT_TIMESTAMP, UNIQUE ("name", "group_id"), FOREIGN KEY ("group_id") REFERENCES "groups" ("id"))`,
+		},
+		{
+			d:    dialect.SQLite3,
+			want: `CREATE TABLE IF NOT EXISTS "people" ("id" integer PRIMARY KEY AUTOINCREMENT, "name" varchar(255) NOT NULL DEFAULT 'anonymous', "active" boolean NOT NULL DEFAULT 1, "group_id" integer, "created_at" timestamp DEFAULT CURRENT_TIMESTAMP, UNIQUE ("name", "group_id"), FOREIGN KEY ("group_id") REFERENCES "groups" ("id"))`,
+		},
+		{
+			d:    dialect.MSSQL,
+			want: `IF OBJECT_ID('people', 'U') IS NULL CREATE TABLE "people" ("id" bigint IDENTITY PRIMARY KEY, "name" nvarchar(255) NOT NULL DEFAULT 'anonymous', "active" bit NOT NULL DEFAULT 1, "group_id" integer, "created_at" datetime2 DEFAULT CURRENT_TIMESTAMP, UNIQUE ("name", "group_id"), FOREIGN KEY ("group_id") REFERENCES "groups" ("id"))`,
+		},
+	} {
+		buf := NewBuffer()
+		err := builder.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.want, query)
+	}
+
+	buf := NewBuffer()
+	err := CreateTable("t").Build(dialect.MySQL, buf)
+	require.Equal(t, ErrColumnNotSpecified, err)
+
+	buf = NewBuffer()
+	err = CreateTable("t").Columns(Col("data", TypeBlob).Default([]byte("x"))).Build(dialect.PostgreSQL, buf)
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestAlterTableStmt(t *testing.T) {
+	builder := AlterTable("people").
+		AddColumn(Col("email", TypeText)).
+		AddColumn(Col("group_id", TypeInt).References("groups", "id")).
+		DropColumn("nickname")
+
+	for _, test := range []struct {
+		d    Dialect
+		want string
+	}{
+		{
+			d:    dialect.MySQL,
+			want: "ALTER TABLE `people` ADD COLUMN `email` text, ADD COLUMN `group_id` integer, ADD FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`), DROP COLUMN `nickname`",
+		},
+		{
+			d:    dialect.PostgreSQL,
+			want: `ALTER TABLE "people" ADD COLUMN "email" text, ADD COLUMN "group_id" integer REFERENCES "groups" ("id"), DROP COLUMN "nickname"`,
+		},
+		{
+			d:    dialect.SQLite3,
+			want: `ALTER TABLE "people" ADD COLUMN "email" text; ALTER TABLE "people" ADD COLUMN "group_id" integer REFERENCES "groups" ("id"); ALTER TABLE "people" DROP COLUMN "nickname"`,
+		},
+		{
+			d:    dialect.MSSQL,
+			want: `ALTER TABLE "people" ADD "email" nvarchar(max); ALTER TABLE "people" ADD "group_id" integer REFERENCES "groups" ("id"); ALTER TABLE "people" DROP COLUMN "nickname"`,
+		},
+	} {
+		buf := NewBuffer()
+		err := builder.Build(test.d, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.want, buf.String())
+	}
+}
+
+func TestCreateIndexStmt(t *testing.T) {
+	for _, test := range []struct {
+		builder *CreateIndexStmt
+		d       Dialect
+		want    string
+		err     error
+	}{
+		{
+			builder: CreateIndex("people_name", "people", "name", "email").Unique(),
+			d:       dialect.MySQL,
+			want:    "CREATE UNIQUE INDEX `people_name` ON `people` (`name`, `email`)",
+		},
+		{
+			builder: CreateIndex("people_name", "people", "name").IfNotExists(),
+			d:       dialect.PostgreSQL,
+			want:    `CREATE INDEX IF NOT EXISTS "people_name" ON "people" ("name")`,
+		},
+		{
+			builder: CreateIndex("people_name", "people", "name").IfNotExists(),
+			d:       dialect.MSSQL,
+			want:    `IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'people_name' AND object_id = OBJECT_ID('people')) CREATE INDEX "people_name" ON "people" ("name")`,
+		},
+		{
+			builder: CreateIndex("people_name", "people", "name").IfNotExists(),
+			d:       dialect.MySQL,
+			err:     ErrNotSupported,
+		},
+		{
+			builder: CreateIndex("", "people", "name"),
+			d:       dialect.PostgreSQL,
+			err:     ErrIndexNotSpecified,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(test.d, buf)
+		require.Equal(t, test.err, err)
+		require.Equal(t, test.want, buf.String())
+	}
+}
+
+func TestSQLite3DDL(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.CreateTable("ddl_groups").Columns(
+		Col("id", TypeInt).AutoIncrement(),
+		Col("code", TypeVarchar(32)).NotNull().Unique(),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	_, err = sess.CreateTable("ddl_people").Columns(
+		Col("id", TypeBigInt).AutoIncrement(),
+		Col("name", TypeVarchar(255)).NotNull().Default("anonymous"),
+		Col("group_id", TypeInt).References("ddl_groups", "id"),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	_, err = sess.AlterTable("ddl_people").AddColumn(Col("email", TypeText)).ExecContext(ctx)
+	require.NoError(t, err)
+
+	_, err = sess.CreateIndex("ddl_people_email", "ddl_people", "email").Unique().IfNotExists().ExecContext(ctx)
+	require.NoError(t, err)
+
+	_, err = sess.InsertInto("ddl_people").Pair("email", "a@example.com").ExecContext(ctx)
+	require.NoError(t, err)
+
+	var name string
+	err = sess.Select("name").From("ddl_people").LoadOneContext(ctx, &name)
+	require.NoError(t, err)
+	require.Equal(t, "anonymous", name)
+
+	missing, err := sess.HasColumns(ctx, "ddl_people", "id", "name", "group_id", "email")
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	fks, err := sess.ForeignKeys(ctx, "ddl_people")
+	require.NoError(t, err)
+	require.Len(t, fks, 1)
+	require.Equal(t, "ddl_groups", fks[0].RefTable)
+}
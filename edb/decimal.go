@@ -0,0 +1,148 @@
+package edb
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Decimal is an arbitrary-precision decimal like
+// github.com/shopspring/decimal.Decimal.
+//
+// A Decimal is interpolated as a numeric literal from String, so
+// money columns do not lose precision through float64. Loading only
+// needs the type to implement sql.Scanner.
+type Decimal interface {
+	String() string
+	Exponent() int32
+}
+
+// isNumeric reports whether s is a decimal literal like -1.25e3.
+func isNumeric(s string) bool {
+	i := 0
+	digits := func() int {
+		n := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+			n++
+		}
+		return n
+	}
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	n := digits()
+	if i < len(s) && s[i] == '.' {
+		i++
+		n += digits()
+	}
+	if n == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+			i++
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// ratString formats r as an exact decimal.
+// It fails if r has no finite decimal representation, like 1/3.
+func ratString(r *big.Rat) (string, error) {
+	if r.IsInt() {
+		return r.Num().String(), nil
+	}
+	denom := new(big.Int).Set(r.Denom())
+	var prec [2]int
+	for i, factor := range []int64{2, 5} {
+		f := big.NewInt(factor)
+		m := new(big.Int)
+		for {
+			q, rem := new(big.Int).QuoRem(denom, f, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			denom = q
+			prec[i]++
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return "", ErrInvalidDecimal
+	}
+	if prec[0] < prec[1] {
+		prec[0] = prec[1]
+	}
+	return r.FloatString(prec[0]), nil
+}
+
+// bigScanner scans a numeric column into *big.Int, *big.Rat,
+// **big.Int or **big.Rat.
+type bigScanner struct {
+	dest interface{}
+}
+
+// bigDest wraps ptr with bigScanner if it points to a big number.
+func bigDest(ptr interface{}) interface{} {
+	switch ptr.(type) {
+	case *big.Int, *big.Rat, **big.Int, **big.Rat:
+		return bigScanner{ptr}
+	}
+	return ptr
+}
+
+// Scan implements the Scanner interface.
+// SQL NULL is only allowed for pointer destinations.
+func (s bigScanner) Scan(value interface{}) error {
+	var str string
+	switch v := value.(type) {
+	case nil:
+		switch dest := s.dest.(type) {
+		case **big.Int:
+			*dest = nil
+			return nil
+		case **big.Rat:
+			*dest = nil
+			return nil
+		}
+		return ErrInvalidDecimal
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case int64:
+		str = strconv.FormatInt(v, 10)
+	case float64:
+		str = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return ErrInvalidDecimal
+	}
+	if !isNumeric(str) {
+		return ErrInvalidDecimal
+	}
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return ErrInvalidDecimal
+	}
+
+	switch dest := s.dest.(type) {
+	case *big.Rat:
+		dest.Set(r)
+	case **big.Rat:
+		*dest = r
+	case *big.Int:
+		if !r.IsInt() {
+			return ErrInvalidDecimal
+		}
+		dest.Set(r.Num())
+	case **big.Int:
+		if !r.IsInt() {
+			return ErrInvalidDecimal
+		}
+		*dest = new(big.Int).Set(r.Num())
+	}
+	return nil
+}
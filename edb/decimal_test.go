@@ -0,0 +1,105 @@
+package edb
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+// testDecimal mimics github.com/shopspring/decimal.Decimal.
+type testDecimal struct {
+	s string
+}
+
+func (d testDecimal) String() string  { return d.s }
+func (d testDecimal) Exponent() int32 { return 0 }
+
+func TestDecimalInterpolate(t *testing.T) {
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, test := range []struct {
+		value interface{}
+		want  string
+	}{
+		{value: n, want: "123456789012345678901234567890"},
+		{value: *big.NewInt(-5), want: "-5"},
+		{value: big.NewRat(1, 8), want: "0.125"},
+		{value: big.NewRat(-3, 20), want: "-0.15"},
+		{value: *big.NewRat(4, 2), want: "2"},
+		{value: testDecimal{"1234567890.0000000001"}, want: "1234567890.0000000001"},
+		{value: (*big.Int)(nil), want: "NULL"},
+		{value: (*big.Rat)(nil), want: "NULL"},
+		{value: float32(0.1), want: "0.1"},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{test.value}, dialect.MySQL)
+		require.NoError(t, err)
+		require.Equal(t, test.want, query)
+	}
+
+	_, err := InterpolateForDialect("?", []interface{}{big.NewRat(1, 3)}, dialect.MySQL)
+	require.Equal(t, ErrInvalidDecimal, err)
+	_, err = InterpolateForDialect("?", []interface{}{testDecimal{"1; DROP TABLE x"}}, dialect.MySQL)
+	require.Equal(t, ErrInvalidDecimal, err)
+}
+
+func TestIsNumeric(t *testing.T) {
+	for _, s := range []string{"1", "-1.5", "+.5", "1.", "1e10", "1.5E-3"} {
+		require.True(t, isNumeric(s), s)
+	}
+	for _, s := range []string{"", "-", ".", "1e", "0x10", "1/3", "1 ", "NaN"} {
+		require.False(t, isNumeric(s), s)
+	}
+}
+
+func TestBigScanner(t *testing.T) {
+	var i big.Int
+	require.NoError(t, bigScanner{&i}.Scan([]byte("10.00")))
+	require.Equal(t, "10", i.String())
+	require.Equal(t, ErrInvalidDecimal, bigScanner{&i}.Scan("10.5"))
+	require.Equal(t, ErrInvalidDecimal, bigScanner{&i}.Scan(nil))
+
+	r := new(big.Rat)
+	require.NoError(t, bigScanner{&r}.Scan(1.25))
+	require.Equal(t, big.NewRat(5, 4), r)
+	require.NoError(t, bigScanner{&r}.Scan(nil))
+	require.Nil(t, r)
+}
+
+func TestSQLite3Decimal(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.CreateTable("decimal_items").Columns(
+		Col("id", TypeInt).AutoIncrement(),
+		Col("count", TypeDecimal(20, 0)),
+		Col("price", TypeDecimal(20, 4)),
+		Col("discount", TypeDecimal(20, 4)),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	type item struct {
+		ID       int64
+		Count    big.Int
+		Price    *big.Rat
+		Discount *big.Rat
+	}
+	in := &item{Price: big.NewRat(5, 4)}
+	in.Count.SetInt64(9223372036854775807)
+	_, err = sess.InsertInto("decimal_items").Columns("count", "price", "discount").Record(in).ExecContext(ctx)
+	require.NoError(t, err)
+
+	var items []item
+	_, err = sess.Select("*").From("decimal_items").LoadContext(ctx, &items)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "9223372036854775807", items[0].Count.String())
+	require.Equal(t, "5/4", items[0].Price.String())
+	require.Nil(t, items[0].Discount)
+
+	var prices []*big.Rat
+	_, err = sess.Select("price").From("decimal_items").LoadContext(ctx, &prices)
+	require.NoError(t, err)
+	require.Equal(t, []*big.Rat{big.NewRat(5, 4)}, prices)
+}
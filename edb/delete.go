@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"strconv"
+
+	"github.com/ego-plugin/store/edb/dialect"
 )
 
 // DeleteStmt builds `DELETE ...`.
@@ -15,8 +17,18 @@ type DeleteStmt struct {
 	raw
 
 	Table      string
+	JoinTable  []tableJoin
 	WhereCond  []Builder
+	Order      []Builder
 	LimitCount int64
+	SoftDelete *SoftDelete
+
+	// KeyColumn identifies the rows to delete on dialects without
+	// `DELETE ... LIMIT`, where Order and Limit are applied in a subquery
+	// like `DELETE FROM a WHERE ctid IN (SELECT ctid FROM a ... LIMIT n)`.
+	// It defaults to ctid on PostgreSQL and rowid on SQLite,
+	// and must be set on MSSQL to delete rows in order.
+	KeyColumn string
 
 	comments Comments
 }
@@ -37,23 +49,193 @@ func (b *DeleteStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
-	buf.WriteString("DELETE FROM ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	whereCond := b.SoftDelete.scope(b.Table, b.WhereCond)
+	_, soft := b.SoftDelete.column(b.Table)
+	if soft && len(b.JoinTable) > 0 {
+		return ErrNotSupported
+	}
+
+	if b.useKeySubquery(d) {
+		return b.buildKeySubquery(d, buf, whereCond, soft)
+	}
+
+	// MSSQL refers to an aliased target by its alias, like `DELETE u FROM users u`
+	aliased := d == dialect.MSSQL && tableAlias(b.Table) != tableName(b.Table)
+
+	if soft {
+		buf.WriteString("UPDATE ")
+		b.writeTop(d, buf)
+		if aliased {
+			buf.WriteString(d.QuoteIdent(tableAlias(b.Table)))
+		} else {
+			buf.WriteString(quoteTargetTable(d, b.Table))
+		}
+		b.writeSoftDelete(d, buf)
+		if aliased {
+			buf.WriteString(" FROM ")
+			buf.WriteString(quoteTable(d, b.Table))
+		}
+		return b.buildTail(d, buf, whereCond)
+	}
+
+	buf.WriteString("DELETE ")
+	b.writeTop(d, buf)
+	if aliased && len(b.JoinTable) == 0 {
+		buf.WriteString(d.QuoteIdent(tableAlias(b.Table)))
+		buf.WriteString(" ")
+	}
+	switch {
+	case len(b.JoinTable) == 0:
+		buf.WriteString("FROM ")
+		if aliased {
+			buf.WriteString(quoteTable(d, b.Table))
+		} else {
+			buf.WriteString(quoteTargetTable(d, b.Table))
+		}
+	case d == dialect.PostgreSQL:
+		// join conditions are moved to WHERE
+		buf.WriteString("FROM ")
+		buf.WriteString(quoteTable(d, b.Table))
+		buf.WriteString(" USING ")
+		on, err := buildJoinList(d, buf, b.JoinTable)
+		if err != nil {
+			return err
+		}
+		whereCond = append(on, whereCond...)
+	default:
+		if d == dialect.MySQL && (len(b.Order) > 0 || b.LimitCount >= 0) {
+			// multiple-table DELETE can't have ORDER BY or LIMIT
+			return ErrNotSupported
+		}
+		buf.WriteString(d.QuoteIdent(tableAlias(b.Table)))
+		buf.WriteString(" FROM ")
+		buf.WriteString(quoteTable(d, b.Table))
+		for _, join := range b.JoinTable {
+			err := join.build(d, buf, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return b.buildTail(d, buf, whereCond)
+}
+
+// writeTop writes `TOP (n) ` of Limit on MSSQL.
+func (b *DeleteStmt) writeTop(d Dialect, buf Buffer) {
+	if d == dialect.MSSQL && b.LimitCount >= 0 {
+		buf.WriteString("TOP (")
+		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
+		buf.WriteString(") ")
+	}
+}
+
+// writeSoftDelete writes ` SET deleted_at = ?` of a soft delete.
+func (b *DeleteStmt) writeSoftDelete(d Dialect, buf Buffer) {
+	buf.WriteString(" SET ")
+	buf.WriteString(d.QuoteIdent(b.SoftDelete.Column))
+	buf.WriteString(" = ")
+	buf.WriteString(placeholder)
+	buf.WriteValue(Now)
+}
 
-	if len(b.WhereCond) > 0 {
+// buildTail writes WHERE, and ORDER BY and LIMIT on MySQL.
+func (b *DeleteStmt) buildTail(d Dialect, buf Buffer, whereCond []Builder) error {
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(whereCond...).Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+	if d == dialect.MySQL {
+		err := buildOrder(d, buf, b.Order)
 		if err != nil {
 			return err
 		}
 	}
-	if b.LimitCount >= 0 {
+	if d == dialect.MySQL && b.LimitCount >= 0 {
 		buf.WriteString(" LIMIT ")
 		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
 	}
 	return nil
 }
 
+// useKeySubquery reports whether the rows to delete are selected
+// in a subquery by KeyColumn.
+func (b *DeleteStmt) useKeySubquery(d Dialect) bool {
+	switch d {
+	case dialect.PostgreSQL:
+		return b.LimitCount >= 0
+	case dialect.SQLite3:
+		return b.LimitCount >= 0 || len(b.JoinTable) > 0
+	case dialect.MSSQL:
+		return b.LimitCount >= 0 && len(b.Order) > 0
+	}
+	return false
+}
+
+// buildKeySubquery builds
+// `DELETE FROM a WHERE key IN (SELECT key FROM a JOIN b ... LIMIT n)`,
+// or `UPDATE a SET deleted_at = ? WHERE key IN (...)` for a soft delete.
+func (b *DeleteStmt) buildKeySubquery(d Dialect, buf Buffer, whereCond []Builder, soft bool) error {
+	key := b.KeyColumn
+	if key == "" {
+		switch d {
+		case dialect.PostgreSQL:
+			key = "ctid"
+		case dialect.SQLite3:
+			key = "rowid"
+		default:
+			return ErrNotSupported
+		}
+	}
+	// the outer table is not aliased, so key is not ambiguous
+	if soft {
+		buf.WriteString("UPDATE ")
+		buf.WriteString(d.QuoteIdent(tableName(b.Table)))
+		b.writeSoftDelete(d, buf)
+	} else {
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(d.QuoteIdent(tableName(b.Table)))
+	}
+	buf.WriteString(" WHERE ")
+	buf.WriteString(d.QuoteIdent(key))
+	buf.WriteString(" IN (SELECT ")
+	if d == dialect.MSSQL {
+		buf.WriteString("TOP (")
+		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
+		buf.WriteString(") ")
+	}
+	buf.WriteString(d.QuoteIdent(tableAlias(b.Table)))
+	buf.WriteString(".")
+	buf.WriteString(d.QuoteIdent(key))
+	buf.WriteString(" FROM ")
+	buf.WriteString(quoteTable(d, b.Table))
+	for _, join := range b.JoinTable {
+		err := join.build(d, buf, false)
+		if err != nil {
+			return err
+		}
+	}
+	if len(whereCond) > 0 {
+		buf.WriteString(" WHERE ")
+		err := And(whereCond...).Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+	err := buildOrder(d, buf, b.Order)
+	if err != nil {
+		return err
+	}
+	if d != dialect.MSSQL && b.LimitCount >= 0 {
+		buf.WriteString(" LIMIT ")
+		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
+	}
+	buf.WriteString(")")
+	return nil
+}
+
 // DeleteFrom creates a DeleteStmt.
 func DeleteFrom(table string) *DeleteStmt {
 	return &DeleteStmt{
@@ -68,6 +250,7 @@ func (sess *Session) DeleteFrom(table string) *DeleteStmt {
 	b.runner = sess
 	b.EventReceiver = sess.EventReceiver
 	b.Dialect = sess.Dialect
+	b.SoftDelete = sess.SoftDelete
 	return b
 }
 
@@ -77,6 +260,7 @@ func (tx *Tx) DeleteFrom(table string) *DeleteStmt {
 	b.runner = tx
 	b.EventReceiver = tx.EventReceiver
 	b.Dialect = tx.Dialect
+	b.SoftDelete = tx.SoftDelete
 	return b
 }
 
@@ -121,6 +305,54 @@ func (b *DeleteStmt) Where(query interface{}, value ...interface{}) *DeleteStmt
 	return b
 }
 
+// Using adds table to delete rows matching the columns of table,
+// which is usually matched in Where. table can be string or Builder,
+// like a subquery with As.
+//
+// It builds `DELETE a FROM a, b` on MySQL and MSSQL,
+// and `DELETE FROM a USING b` on PostgreSQL. On SQLite,
+// the rows are selected in a subquery by KeyColumn.
+func (b *DeleteStmt) Using(table interface{}) *DeleteStmt {
+	b.JoinTable = append(b.JoinTable, tableJoin{table: table})
+	return b
+}
+
+// Join joins table to delete rows matching the columns of table.
+// table can be string or Builder, like a subquery with As.
+// on can be Builder or string.
+//
+// It builds `DELETE a FROM a JOIN b ON ...` on MySQL and MSSQL, where
+// the deleted table is referred by its alias, and
+// `DELETE FROM a USING b WHERE ...` on PostgreSQL with on moved to WHERE.
+// On SQLite, the rows are selected in a subquery by KeyColumn.
+func (b *DeleteStmt) Join(table, on interface{}) *DeleteStmt {
+	b.JoinTable = append(b.JoinTable, tableJoin{table: table, on: on})
+	return b
+}
+
+func (b *DeleteStmt) OrderAsc(col string) *DeleteStmt {
+	b.Order = append(b.Order, order(col, asc))
+	return b
+}
+
+func (b *DeleteStmt) OrderDesc(col string) *DeleteStmt {
+	b.Order = append(b.Order, order(col, desc))
+	return b
+}
+
+// OrderBy specifies columns for ordering, which only matters with Limit.
+func (b *DeleteStmt) OrderBy(col string) *DeleteStmt {
+	b.Order = append(b.Order, orderItem{column: col})
+	return b
+}
+
+// Limit limits the number of deleted rows, like
+// `DELETE FROM events WHERE ... LIMIT 1000` for batched purges.
+//
+// It builds `DELETE ... LIMIT n` on MySQL, and `DELETE TOP (n)` on MSSQL.
+// On PostgreSQL and SQLite, or on MSSQL with Order, the rows are selected
+// in a subquery by KeyColumn. On MySQL, it can't be used with Join or Using.
+// Soft deletes are limited the same way.
 func (b *DeleteStmt) Limit(n uint64) *DeleteStmt {
 	b.LimitCount = int64(n)
 	return b
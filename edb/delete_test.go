@@ -22,3 +22,180 @@ func BenchmarkDeleteSQL(b *testing.B) {
 		DeleteFrom("table").Where(Eq("a", 1)).Build(dialect.MySQL, buf)
 	}
 }
+
+func TestDeleteJoinLimit(t *testing.T) {
+	for _, test := range []struct {
+		builder *DeleteStmt
+		d       Dialect
+		query   string
+	}{
+		{
+			builder: DeleteFrom("events").Where(Lt("created_at", 100)).OrderAsc("id").Limit(1000),
+			d:       dialect.MySQL,
+			query:   "DELETE FROM `events` WHERE (`created_at` < 100) ORDER BY id ASC LIMIT 1000",
+		},
+		{
+			builder: DeleteFrom("events").Where(Lt("created_at", 100)).Limit(1000),
+			d:       dialect.PostgreSQL,
+			query:   `DELETE FROM "events" WHERE "ctid" IN (SELECT "events"."ctid" FROM "events" WHERE ("created_at" < 100) LIMIT 1000)`,
+		},
+		{
+			builder: DeleteFrom("events e").Where(Lt("e.created_at", 100)).OrderAsc("e.id").Limit(10),
+			d:       dialect.SQLite3,
+			query:   `DELETE FROM "events" WHERE "rowid" IN (SELECT "e"."rowid" FROM "events" "e" WHERE ("e"."created_at" < 100) ORDER BY e.id ASC LIMIT 10)`,
+		},
+		{
+			builder: DeleteFrom("events").Where(Lt("created_at", 100)).Limit(10),
+			d:       dialect.MSSQL,
+			query:   `DELETE TOP (10) FROM "events" WHERE ("created_at" < 100)`,
+		},
+		{
+			builder: &DeleteStmt{Table: "events", KeyColumn: "id", LimitCount: 10, Order: []Builder{order("id", asc)}},
+			d:       dialect.MSSQL,
+			query:   `DELETE FROM "events" WHERE "id" IN (SELECT TOP (10) "events"."id" FROM "events" ORDER BY id ASC)`,
+		},
+		{
+			builder: DeleteFrom("users u").Join("accounts a", "a.user_id = u.id").Where(Eq("a.closed", true)),
+			d:       dialect.MySQL,
+			query:   "DELETE `u` FROM `users` `u` JOIN `accounts` `a` ON a.user_id = u.id WHERE (`a`.`closed` = 1)",
+		},
+		{
+			builder: DeleteFrom("users u").Join("accounts a", "a.user_id = u.id").Where(Eq("a.closed", true)),
+			d:       dialect.PostgreSQL,
+			query:   `DELETE FROM "users" "u" USING "accounts" "a" WHERE (a.user_id = u.id) AND ("a"."closed" = TRUE)`,
+		},
+		{
+			builder: DeleteFrom("users").Using("accounts").Where("accounts.user_id = users.id"),
+			d:       dialect.MSSQL,
+			query:   `DELETE "users" FROM "users", "accounts" WHERE (accounts.user_id = users.id)`,
+		},
+		{
+			builder: DeleteFrom("users u").Join("accounts a", "a.user_id = u.id").Limit(5),
+			d:       dialect.SQLite3,
+			query:   `DELETE FROM "users" WHERE "rowid" IN (SELECT "u"."rowid" FROM "users" "u" JOIN "accounts" "a" ON a.user_id = u.id LIMIT 5)`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	err := DeleteFrom("users u").Join("accounts a", "a.user_id = u.id").Limit(1).Build(dialect.MySQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+	err = DeleteFrom("events").OrderAsc("id").Limit(1).Build(dialect.MSSQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSQLite3DeleteJoinLimit(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE events (id integer, user_id integer)`,
+		`CREATE TABLE users (id integer, banned integer)`,
+		`INSERT INTO events VALUES (1, 1), (2, 2), (3, 1), (4, 2), (5, 1)`,
+		`INSERT INTO users VALUES (1, 0), (2, 1)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	result, err := sess.DeleteFrom("events e").Join("users u", "u.id = e.user_id").Where(Eq("u.banned", true)).Exec()
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	_, err = sess.DeleteFrom("events").OrderDesc("id").Limit(2).Exec()
+	require.NoError(t, err)
+
+	var ids []int64
+	_, err = sess.Select("id").From("events").OrderAsc("id").Load(&ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, ids)
+}
+
+func TestDeleteSoftDeleteLimit(t *testing.T) {
+	soft := &SoftDelete{Column: "deleted_at"}
+	for _, test := range []struct {
+		builder *DeleteStmt
+		d       Dialect
+		query   string
+	}{
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")).OrderAsc("u.id").Limit(10),
+			d:       dialect.MySQL,
+			query:   "UPDATE `users` `u` SET `deleted_at` = ? WHERE (`u`.`name` = ?) AND (`u`.`deleted_at` IS NULL) ORDER BY u.id ASC LIMIT 10",
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")).Limit(10),
+			d:       dialect.PostgreSQL,
+			query:   `UPDATE "users" SET "deleted_at" = ? WHERE "ctid" IN (SELECT "u"."ctid" FROM "users" "u" WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL) LIMIT 10)`,
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")).Limit(10),
+			d:       dialect.SQLite3,
+			query:   `UPDATE "users" SET "deleted_at" = ? WHERE "rowid" IN (SELECT "u"."rowid" FROM "users" "u" WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL) LIMIT 10)`,
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")).Limit(10),
+			d:       dialect.MSSQL,
+			query:   `UPDATE TOP (10) "u" SET "deleted_at" = ? FROM "users" "u" WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL)`,
+		},
+		{
+			builder: &DeleteStmt{Table: "users u", KeyColumn: "id", LimitCount: 10, Order: []Builder{order("u.id", asc)}},
+			d:       dialect.MSSQL,
+			query:   `UPDATE "users" SET "deleted_at" = ? WHERE "id" IN (SELECT TOP (10) "u"."id" FROM "users" "u" WHERE ("u"."deleted_at" IS NULL) ORDER BY u.id ASC)`,
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")),
+			d:       dialect.MySQL,
+			query:   "UPDATE `users` `u` SET `deleted_at` = ? WHERE (`u`.`name` = ?) AND (`u`.`deleted_at` IS NULL)",
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")),
+			d:       dialect.PostgreSQL,
+			query:   `UPDATE "users" "u" SET "deleted_at" = ? WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL)`,
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")),
+			d:       dialect.SQLite3,
+			query:   `UPDATE "users" AS "u" SET "deleted_at" = ? WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL)`,
+		},
+		{
+			builder: DeleteFrom("users u").Where(Eq("u.name", "a")),
+			d:       dialect.MSSQL,
+			query:   `UPDATE "u" SET "deleted_at" = ? FROM "users" "u" WHERE ("u"."name" = ?) AND ("u"."deleted_at" IS NULL)`,
+		},
+	} {
+		test.builder.SoftDelete = soft
+		buf := NewBuffer()
+		err := test.builder.Build(test.d, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+	}
+
+	b := DeleteFrom("users").OrderAsc("id").Limit(1)
+	b.SoftDelete = soft
+	err := b.Build(dialect.MSSQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestDeleteAlias(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{d: dialect.MySQL, query: "DELETE FROM `events` `e` WHERE (`e`.`id` = ?)"},
+		{d: dialect.PostgreSQL, query: `DELETE FROM "events" "e" WHERE ("e"."id" = ?)`},
+		{d: dialect.SQLite3, query: `DELETE FROM "events" AS "e" WHERE ("e"."id" = ?)`},
+		{d: dialect.MSSQL, query: `DELETE "e" FROM "events" "e" WHERE ("e"."id" = ?)`},
+	} {
+		buf := NewBuffer()
+		err := DeleteFrom("events e").Where(Eq("e.id", 1)).Build(test.d, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+	}
+}
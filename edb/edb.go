@@ -57,10 +57,16 @@ type Connection struct {
 // A custom EventReceiver can be set.
 //
 // Timeout specifies max duration for an operation like Select.
+//
+// SoftDelete enables soft deletion for statements created by the session.
+//
+// SafeIdent enables safe identifier mode for SelectStmt created by the session.
 type Session struct {
 	*Connection
 	EventReceiver
-	Timeout time.Duration
+	Timeout    time.Duration
+	SoftDelete *SoftDelete
+	SafeIdent  bool
 }
 
 // GetTimeout returns current timeout enforced in session.
@@ -197,6 +203,14 @@ func queryRows(ctx context.Context, runner runner, log EventReceiver, builder Bu
 }
 
 func query(ctx context.Context, runner runner, log EventReceiver, builder Builder, d Dialect, dest interface{}) (int, error) {
+	return queryFunc(ctx, runner, log, builder, d, func(rows *sql.Rows) (int, error) {
+		return Load(rows, dest)
+	})
+}
+
+// queryFunc runs the query of builder, and loads the rows with load,
+// which must close them.
+func queryFunc(ctx context.Context, runner runner, log EventReceiver, builder Builder, d Dialect, load func(*sql.Rows) (int, error)) (int, error) {
 	timeout := runner.GetTimeout()
 	if timeout > 0 {
 		var cancel func()
@@ -208,7 +222,7 @@ func query(ctx context.Context, runner runner, log EventReceiver, builder Builde
 	if err != nil {
 		return 0, err
 	}
-	count, err := Load(rows, dest)
+	count, err := load(rows)
 	if err != nil {
 		return 0, log.EventErrKv("dbr.select.load.scan", err, kvs{
 			"sql": query,
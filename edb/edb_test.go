@@ -45,6 +45,18 @@ var (
 	testSession = []*Session{mysqlSession, postgresSession, sqlite3Session, mssqlSession}
 )
 
+// memorySQLite returns a session of a new in-memory SQLite database.
+// It has only one connection, as each connection to :memory: opens
+// a separate database.
+func memorySQLite(t *testing.T) *Session {
+	t.Helper()
+	conn, err := Open("sqlite3", ":memory:", nil)
+	require.NoError(t, err)
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	return conn.NewSession(nil)
+}
+
 type dbrPerson struct {
 	Id    int64
 	Name  string
@@ -8,9 +8,14 @@ var (
 	ErrNotSupported       = errors.New("edb: not supported")
 	ErrTableNotSpecified  = errors.New("edb: table not specified")
 	ErrColumnNotSpecified = errors.New("edb: column not specified")
+	ErrIndexNotSpecified  = errors.New("edb: index not specified")
 	ErrInvalidPointer     = errors.New("edb: attempt to load into an invalid pointer")
 	ErrPlaceholderCount   = errors.New("edb: wrong placeholder count")
 	ErrInvalidSliceLength = errors.New("edb: length of slice is 0. length must be >= 1")
 	ErrCantConvertToTime  = errors.New("edb: can't convert to time.Time")
 	ErrInvalidTimestring  = errors.New("edb: invalid time string")
+	ErrInvalidDecimal     = errors.New("edb: invalid decimal")
+	ErrInvalidPreload     = errors.New("edb: preload key or field not found")
+	ErrInvalidIdentifier  = errors.New("edb: invalid identifier; use Expr for raw sql")
+	ErrInvalidTuple       = errors.New("edb: tuple length does not match columns")
 )
@@ -0,0 +1,214 @@
+// Package gen generates Go model structs and column constants from a live
+// database schema.
+package gen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+
+	"github.com/ego-plugin/store/edb"
+)
+
+// Column describes a table column found in the schema.
+type Column struct {
+	Name     string
+	DataType string
+	Nullable bool
+}
+
+// Table describes a table and its columns in ordinal order.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// LoadTables reads the tables of the current schema.
+// If name is not empty, only the named tables are returned.
+func LoadTables(ctx context.Context, sess *edb.Session, name ...string) ([]Table, error) {
+	names, err := sess.Tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []Table
+	for _, table := range names {
+		if len(name) > 0 && !edb.IsSliceContainsString(table, name...) {
+			continue
+		}
+		columns, err := sess.Columns(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		t := Table{Name: table}
+		for _, col := range columns {
+			t.Columns = append(t.Columns, Column{
+				Name:     col.Name,
+				DataType: col.DataType,
+				Nullable: col.Nullable,
+			})
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// GoType returns the Go type used for a column of dataType.
+// Nullable columns are mapped to edb null types.
+func GoType(dataType string, nullable bool) string {
+	t := strings.ToLower(strings.TrimSpace(dataType))
+	if i := strings.IndexByte(t, '('); i >= 0 {
+		t = t[:i]
+	}
+	t = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(t), "unsigned"))
+
+	switch {
+	case t == "bool" || t == "boolean" || t == "bit":
+		if nullable {
+			return "edb.NullBool"
+		}
+		return "bool"
+	case integerTypes[t]:
+		if nullable {
+			return "edb.NullInt64"
+		}
+		return "int64"
+	case t == "real" || t == "float" || t == "double" || strings.HasPrefix(t, "double ") ||
+		strings.HasPrefix(t, "float"):
+		if nullable {
+			return "edb.NullFloat64"
+		}
+		return "float64"
+	case t == "date" || t == "datetime" || strings.HasPrefix(t, "timestamp") ||
+		strings.HasPrefix(t, "time "):
+		if nullable {
+			return "edb.NullTime"
+		}
+		return "time.Time"
+	case strings.HasSuffix(t, "blob") || strings.HasSuffix(t, "binary") || t == "bytea":
+		return "[]byte"
+	}
+	// text, varchar, decimal (kept as string to avoid losing precision) and
+	// everything else
+	if nullable {
+		return "edb.NullString"
+	}
+	return "string"
+}
+
+var integerTypes = map[string]bool{
+	"tinyint":     true,
+	"smallint":    true,
+	"mediumint":   true,
+	"int":         true,
+	"integer":     true,
+	"bigint":      true,
+	"int2":        true,
+	"int4":        true,
+	"int8":        true,
+	"smallserial": true,
+	"serial":      true,
+	"bigserial":   true,
+	"year":        true,
+}
+
+var commonInitialisms = map[string]bool{
+	"api":  true,
+	"html": true,
+	"http": true,
+	"id":   true,
+	"ip":   true,
+	"json": true,
+	"sql":  true,
+	"uri":  true,
+	"url":  true,
+	"uuid": true,
+	"xml":  true,
+}
+
+// Identifier converts a snake_case name into an exported Go identifier.
+func Identifier(name string) string {
+	var buf strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		lower := strings.ToLower(part)
+		if commonInitialisms[lower] {
+			buf.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		buf.WriteString(strings.ToUpper(lower[:1]))
+		buf.WriteString(lower[1:])
+	}
+	s := buf.String()
+	if s == "" || '0' <= s[0] && s[0] <= '9' {
+		s = "X" + s
+	}
+	return s
+}
+
+// Generate writes a gofmt-ed Go file of package pkg for tables to w.
+//
+// For each table it emits a struct with db tags, a constant holding the
+// table name and a constant for each column, so that a renamed column breaks
+// the build instead of a query:
+//
+//	sess.Select(model.UsersColumnID).From(model.UsersTable).
+//		Where(edb.Eq(model.UsersColumnName, name))
+func Generate(w io.Writer, pkg string, tables []Table) error {
+	var body bytes.Buffer
+	imports := make(map[string]bool)
+
+	for _, t := range tables {
+		typeName := Identifier(t.Name)
+
+		fmt.Fprintf(&body, "// %sTable is the name of the %s table.\n", typeName, t.Name)
+		fmt.Fprintf(&body, "const %sTable = %q\n\n", typeName, t.Name)
+
+		fmt.Fprintf(&body, "// Columns of the %s table.\n", t.Name)
+		body.WriteString("const (\n")
+		for _, col := range t.Columns {
+			fmt.Fprintf(&body, "%sColumn%s = %q\n", typeName, Identifier(col.Name), col.Name)
+		}
+		body.WriteString(")\n\n")
+
+		fmt.Fprintf(&body, "// %s is a row of the %s table.\n", typeName, t.Name)
+		fmt.Fprintf(&body, "type %s struct {\n", typeName)
+		for _, col := range t.Columns {
+			goType := GoType(col.DataType, col.Nullable)
+			switch {
+			case strings.HasPrefix(goType, "edb."):
+				imports["github.com/ego-plugin/store/edb"] = true
+			case strings.HasPrefix(goType, "time."):
+				imports["time"] = true
+			}
+			fmt.Fprintf(&body, "%s %s `db:%q`\n", Identifier(col.Name), goType, col.Name)
+		}
+		body.WriteString("}\n\n")
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by edb-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	if len(imports) > 0 {
+		src.WriteString("import (\n")
+		if imports["time"] {
+			src.WriteString("\"time\"\n\n")
+		}
+		if imports["github.com/ego-plugin/store/edb"] {
+			src.WriteString("\"github.com/ego-plugin/store/edb\"\n")
+		}
+		src.WriteString(")\n\n")
+	}
+	src.Write(body.Bytes())
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
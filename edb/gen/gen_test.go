@@ -0,0 +1,121 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ego-plugin/store/edb"
+)
+
+func TestGoType(t *testing.T) {
+	for _, test := range []struct {
+		in       string
+		nullable bool
+		want     string
+	}{
+		{in: "INTEGER", want: "int64"},
+		{in: "bigint unsigned", want: "int64"},
+		{in: "int", nullable: true, want: "edb.NullInt64"},
+		{in: "varchar(255)", want: "string"},
+		{in: "character varying", nullable: true, want: "edb.NullString"},
+		{in: "decimal(10,2)", want: "string"},
+		{in: "double precision", want: "float64"},
+		{in: "float", nullable: true, want: "edb.NullFloat64"},
+		{in: "timestamp without time zone", want: "time.Time"},
+		{in: "datetime", nullable: true, want: "edb.NullTime"},
+		{in: "boolean", nullable: true, want: "edb.NullBool"},
+		{in: "bytea", nullable: true, want: "[]byte"},
+		{in: "point", want: "string"},
+	} {
+		require.Equal(t, test.want, GoType(test.in, test.nullable), test.in)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want string
+	}{
+		{in: "id", want: "ID"},
+		{in: "user_id", want: "UserID"},
+		{in: "avatar_url", want: "AvatarURL"},
+		{in: "order_items", want: "OrderItems"},
+		{in: "2fa", want: "X2fa"},
+	} {
+		require.Equal(t, test.want, Identifier(test.in))
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var buf bytes.Buffer
+	err := Generate(&buf, "model", []Table{
+		{
+			Name: "users",
+			Columns: []Column{
+				{Name: "id", DataType: "integer"},
+				{Name: "name", DataType: "varchar(255)", Nullable: true},
+				{Name: "created_at", DataType: "timestamp"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by edb-gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+
+	"github.com/ego-plugin/store/edb"
+)
+
+// UsersTable is the name of the users table.
+const UsersTable = "users"
+
+// Columns of the users table.
+const (
+	UsersColumnID        = "id"
+	UsersColumnName      = "name"
+	UsersColumnCreatedAt = "created_at"
+)
+
+// Users is a row of the users table.
+type Users struct {
+	ID        int64          `+"`db:\"id\"`"+`
+	Name      edb.NullString `+"`db:\"name\"`"+`
+	CreatedAt time.Time      `+"`db:\"created_at\"`"+`
+}
+`, buf.String())
+}
+
+func TestLoadTablesSQLite3(t *testing.T) {
+	conn, err := edb.Open("sqlite3", ":memory:", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+	sess := conn.NewSession(nil)
+
+	_, err = sess.Exec(`CREATE TABLE gen_people (
+		id integer PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		email varchar(255)
+	)`)
+	require.NoError(t, err)
+
+	tables, err := LoadTables(context.Background(), sess)
+	require.NoError(t, err)
+	require.Equal(t, []Table{
+		{
+			Name: "gen_people",
+			Columns: []Column{
+				{Name: "id", DataType: "integer"},
+				{Name: "name", DataType: "varchar(255)"},
+				{Name: "email", DataType: "varchar(255)", Nullable: true},
+			},
+		},
+	}, tables)
+}
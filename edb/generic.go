@@ -0,0 +1,63 @@
+package edb
+
+import (
+	"context"
+	"database/sql"
+)
+
+// One loads the first row of stmt into a T.
+// Like LoadOne, it returns ErrNotFound if there is no row.
+func One[T any](ctx context.Context, stmt *SelectStmt) (T, error) {
+	var v T
+	err := stmt.LoadOneContext(ctx, &v)
+	return v, err
+}
+
+// All loads all rows of stmt into a slice of T.
+func All[T any](ctx context.Context, stmt *SelectStmt) ([]T, error) {
+	var v []T
+	_, err := stmt.LoadContext(ctx, &v)
+	return v, err
+}
+
+// Map loads all rows of stmt into a map, where the first column is the key,
+// and the rest of columns are loaded into the value.
+func Map[K comparable, V any](ctx context.Context, stmt *SelectStmt) (map[K]V, error) {
+	var v map[K]V
+	_, err := stmt.LoadContext(ctx, &v)
+	return v, err
+}
+
+// Column loads the first column of all rows of stmt into a slice of T.
+//
+// Unlike All, T is always loaded from the first column,
+// even if it is a struct like time.Time.
+func Column[T any](ctx context.Context, stmt *SelectStmt) ([]T, error) {
+	var l []T
+	_, err := queryFunc(ctx, stmt.runner, stmt.EventReceiver, stmt, stmt.Dialect, func(rows *sql.Rows) (int, error) {
+		defer rows.Close()
+
+		column, err := rows.Columns()
+		if err != nil {
+			return 0, err
+		}
+		ptr := make([]interface{}, len(column))
+		for i := range ptr {
+			ptr[i] = dummyDest
+		}
+		for rows.Next() {
+			var v T
+			ptr[0] = bigDest(&v)
+			err = rows.Scan(ptr...)
+			if err != nil {
+				return 0, err
+			}
+			l = append(l, v)
+		}
+		return len(l), rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
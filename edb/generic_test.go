@@ -0,0 +1,78 @@
+package edb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite3Generic(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	for _, v := range []string{
+		`CREATE TABLE people (id integer, name text, created_at timestamp)`,
+		`INSERT INTO people VALUES (1, 'a', '2020-01-01 00:00:00'), (2, 'b', '2020-01-02 00:00:00')`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	type person struct {
+		ID        int64
+		Name      string
+		CreatedAt time.Time
+	}
+	day1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	p, err := One[person](ctx, sess.Select("*").From("people").Where(Eq("id", 2)))
+	require.NoError(t, err)
+	require.Equal(t, person{ID: 2, Name: "b", CreatedAt: day2}, p)
+
+	_, err = One[person](ctx, sess.Select("*").From("people").Where(Eq("id", 3)))
+	require.Equal(t, ErrNotFound, err)
+
+	name, err := One[string](ctx, sess.Select("name").From("people").Where(Eq("id", 1)))
+	require.NoError(t, err)
+	require.Equal(t, "a", name)
+
+	people, err := All[*person](ctx, sess.Select("*").From("people").OrderAsc("id"))
+	require.NoError(t, err)
+	require.Equal(t, []*person{{ID: 1, Name: "a", CreatedAt: day1}, {ID: 2, Name: "b", CreatedAt: day2}}, people)
+
+	m, err := Map[int64, string](ctx, sess.Select("id", "name").From("people"))
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "a", 2: "b"}, m)
+
+	created, err := Column[time.Time](ctx, sess.Select("created_at", "id").From("people").OrderAsc("id"))
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{day1, day2}, created)
+
+	iter, err := sess.Select("*").From("people").OrderAsc("id").Iterate()
+	require.NoError(t, err)
+	defer iter.Close()
+	var got []person
+	for iter.Next() {
+		var p person
+		require.NoError(t, iter.Scan(&p))
+		got = append(got, p)
+	}
+	require.NoError(t, iter.Err())
+	require.Len(t, got, 2)
+}
+
+func TestTypeMetaCache(t *testing.T) {
+	typ := reflect.TypeOf(map[string][]int64{})
+	m := getTypeMeta(typ)
+	require.True(t, m.isMap)
+	require.True(t, m.isMapOfSlices)
+	require.False(t, m.isSlice)
+	require.Same(t, m, getTypeMeta(typ))
+
+	require.False(t, getTypeMeta(reflect.TypeOf(Array[int64]{})).isSlice)
+	require.False(t, getTypeMeta(reflect.TypeOf([]byte{})).isSlice)
+}
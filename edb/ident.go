@@ -1,5 +1,12 @@
 package edb
 
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
 // I is quoted identifier
 type I string
 
@@ -23,3 +30,85 @@ func as(expr interface{}, alias string) Builder {
 		return nil
 	})
 }
+
+// isIdent reports whether s can be quoted as one identifier.
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteIdentPath quotes an identifier like `col`, `t.col` or `t.*`.
+func quoteIdentPath(d Dialect, s string) (string, error) {
+	part := strings.Split(s, ".")
+	for i := range part {
+		if part[i] == "*" && i == len(part)-1 {
+			continue
+		}
+		if !isIdent(part[i]) {
+			return "", ErrInvalidIdentifier
+		}
+		part[i] = d.QuoteIdent(part[i])
+	}
+	return strings.Join(part, "."), nil
+}
+
+// quoteSelectIdent quotes a column or table in safe identifier mode.
+// It can be written like `t.col`, `t.*`, `col AS alias` or `table alias`.
+func quoteSelectIdent(d Dialect, s string) (string, error) {
+	part := strings.Fields(s)
+	var alias, sep string
+	switch {
+	case len(part) == 1:
+	case len(part) == 2:
+		alias, sep = part[1], " "
+	case len(part) == 3 && strings.EqualFold(part[1], "AS"):
+		alias, sep = part[2], " AS "
+	default:
+		return "", ErrInvalidIdentifier
+	}
+	name, err := quoteIdentPath(d, part[0])
+	if err != nil {
+		return "", err
+	}
+	if alias == "" {
+		return name, nil
+	}
+	if !isIdent(alias) || strings.HasSuffix(part[0], "*") {
+		return "", ErrInvalidIdentifier
+	}
+	return name + sep + d.QuoteIdent(alias), nil
+}
+
+// quoteTable quotes a table written like `users` or `users u`.
+func quoteTable(d Dialect, table string) string {
+	s, err := quoteSelectIdent(d, table)
+	if err != nil {
+		return d.QuoteIdent(table)
+	}
+	return s
+}
+
+// quoteTargetTable quotes the table of UPDATE or DELETE written like
+// `users u`. SQLite requires AS before the alias of the target table.
+func quoteTargetTable(d Dialect, table string) string {
+	if d == dialect.SQLite3 && tableAlias(table) != tableName(table) {
+		return d.QuoteIdent(tableName(table)) + " AS " + d.QuoteIdent(tableAlias(table))
+	}
+	return quoteTable(d, table)
+}
+
+// tableName returns the name of a table written like `users u`.
+func tableName(table string) string {
+	part := strings.Fields(table)
+	if len(part) == 0 {
+		return ""
+	}
+	return part[0]
+}
@@ -24,6 +24,9 @@ type InsertStmt struct {
 	ReturnColumn []string
 	RecordID     *int64
 	comments     Comments
+
+	// recordColumns is true if Column is taken from the records
+	recordColumns bool
 }
 
 type InsertBuilder = InsertStmt
@@ -158,6 +161,7 @@ func (tx *Tx) InsertBySql(query string, value ...interface{}) *InsertStmt {
 
 func (b *InsertStmt) Columns(column ...string) *InsertStmt {
 	b.Column = column
+	b.recordColumns = false
 	return b
 }
 
@@ -181,55 +185,81 @@ func (b *InsertStmt) Values(value ...interface{}) *InsertStmt {
 }
 
 // StructValues  Struct 里 tag 的 db 不等于空的写入 values
+//
+// db tag options are honored: readonly fields are skipped, omitempty and pk
+// fields are skipped if they are the zero value, and autocreatetime or
+// autoupdatetime fields which are the zero value are written as Now.
 func (b *InsertStmt) ScanStruct(value interface{}, column ...string) *InsertStmt {
-	valueType := reflect.TypeOf(value)
-	valueValue := reflect.ValueOf(value)
-	if valueType.Kind() == reflect.Ptr {
-		valueType = valueType.Elem()
-		valueValue = valueValue.Elem()
-	}
-	if valueType.Kind() != reflect.Struct {
+	valueValue := reflect.Indirect(reflect.ValueOf(value))
+	if valueValue.Kind() != reflect.Struct {
 		return b
 	}
-	columns := make([]string, 0)
-	values := make([]interface{}, 0)
-	columnLen := len(column)
-	for index := 0; index < valueType.NumField(); index++ {
-		typeField := valueType.Field(index)
-		valueField := valueValue.Field(index)
-		columnName := typeField.Tag.Get("db")
-		columnSlice := strings.SplitN(columnName, ",", 2)
-		columnName = columnSlice[0]
-		if columnName == "" || columnName == "id" || columnName == "-" {
-			continue
-		}
-		// 检查列是否存在
-		if columnLen > 0 && !IsSliceContainsString(columnName, column...) {
-			continue
-		}
-		columns = append(columns, columnName)
-		values = append(values, valueField.Interface())
-	}
+	columns, values := structValues(valueValue, column, false)
 	return b.Columns(columns...).Values(values...)
 }
 
 // Record adds a tuple for columns from a struct.
 //
-// If there is a field called "Id" or "ID" in the struct,
+// If Columns is not set, the columns are taken from the tagged fields
+// like ScanStruct, and they are the columns written by any of the records.
+// Otherwise the listed columns are written, except readonly fields.
+//
+// In both cases, autocreatetime or autoupdatetime fields which are the
+// zero value are written as Now, and omitempty or pk fields which are the
+// zero value are written as DEFAULT, which SQLite does not support.
+// So records are written the same way whatever their order.
+//
+// If there is a field tagged with pk, or called "Id" or "ID" in the struct,
 // it will be set to LastInsertId.
 func (b *InsertStmt) Record(structValue interface{}) *InsertStmt {
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 
 	if v.Kind() == reflect.Struct {
+		s := newTagStore()
+		if len(b.Column) == 0 && len(b.Value) == 0 {
+			b.recordColumns = true
+		}
+		if b.recordColumns {
+			columns, _ := structValues(v, nil, false)
+			for _, col := range columns {
+				if IsSliceContainsString(col, b.Column...) {
+					continue
+				}
+				// earlier records did not write the column
+				b.Column = append(b.Column, col)
+				for i := range b.Value {
+					b.Value[i] = append(b.Value[i], defaultValue{})
+				}
+			}
+		} else if len(b.Value) == 0 {
+			column := make([]string, 0, len(b.Column))
+			for _, col := range b.Column {
+				if !s.columnTag(v.Type(), col).readOnly {
+					column = append(column, col)
+				}
+			}
+			b.Column = column
+		}
+
 		found := make([]interface{}, len(b.Column)+1)
 		// ID is recommended by golint here
-		s := newTagStore()
-		s.findValueByName(v, append(b.Column, "id"), found, false)
+		s.findValueByName(v, append(b.Column[:len(b.Column):len(b.Column)], s.pkName(v.Type())), found, false)
 
 		value := found[:len(found)-1]
-		for i, v := range value {
-			if v != nil {
-				value[i] = v.(reflect.Value).Interface()
+		for i, field := range value {
+			if field == nil {
+				continue
+			}
+			fieldValue := field.(reflect.Value)
+			tag := s.columnTag(v.Type(), b.Column[i])
+			isZero := fieldValue.IsZero()
+			switch {
+			case isZero && (tag.autoCreateTime || tag.autoUpdateTime):
+				value[i] = Now
+			case isZero && (tag.omitEmpty || tag.pk):
+				value[i] = defaultValue{}
+			default:
+				value[i] = fieldValue.Interface()
 			}
 		}
 
@@ -246,6 +276,17 @@ func (b *InsertStmt) Record(structValue interface{}) *InsertStmt {
 	return b
 }
 
+// defaultValue is `DEFAULT` in VALUES.
+type defaultValue struct{}
+
+func (defaultValue) Build(d Dialect, buf Buffer) error {
+	if d == dialect.SQLite3 {
+		return ErrNotSupported
+	}
+	buf.WriteString("DEFAULT")
+	return nil
+}
+
 // Returning specifies the returning columns for postgres/mssql.
 func (b *InsertStmt) Returning(column ...string) *InsertStmt {
 	b.ReturnColumn = column
@@ -2,6 +2,7 @@ package edb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ego-plugin/store/edb/dialect"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,85 @@ func TestInsertStmt(t *testing.T) {
 	require.Equal(t, []interface{}{1, "one", 2, "two"}, buf.Value())
 }
 
+type taggedRecord struct {
+	ID        int64     `db:"id,pk"`
+	Name      string    `db:"name"`
+	Nickname  string    `db:"nickname,omitempty"`
+	Version   int       `db:",readonly"`
+	CreatedAt time.Time `db:",autocreatetime"`
+	UpdatedAt time.Time `db:",autoupdatetime"`
+	Ignored   string
+}
+
+func TestInsertScanStructTags(t *testing.T) {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		builder *InsertStmt
+		query   string
+		value   []interface{}
+	}{
+		{
+			builder: InsertInto("t").ScanStruct(&taggedRecord{Name: "a", Version: 1}),
+			query:   "INSERT INTO `t` (`name`,`created_at`,`updated_at`) VALUES (?,?,?)",
+			value:   []interface{}{"a", Now, Now},
+		},
+		{
+			builder: InsertInto("t").ScanStruct(taggedRecord{ID: 1, Name: "a", Nickname: "b", CreatedAt: created}),
+			query:   "INSERT INTO `t` (`id`,`name`,`nickname`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)",
+			value:   []interface{}{int64(1), "a", "b", created, Now},
+		},
+		{
+			builder: InsertInto("t").ScanStruct(&taggedRecord{Name: "a"}, "name", "version"),
+			query:   "INSERT INTO `t` (`name`) VALUES (?)",
+			value:   []interface{}{"a"},
+		},
+		{
+			builder: InsertInto("t").Record(&taggedRecord{Name: "a"}),
+			query:   "INSERT INTO `t` (`name`,`created_at`,`updated_at`) VALUES (?,?,?)",
+			value:   []interface{}{"a", Now, Now},
+		},
+		{
+			builder: InsertInto("t").Columns("name", "version", "nickname", "created_at").Record(&taggedRecord{Name: "a", Version: 2}),
+			query:   "INSERT INTO `t` (`name`,`nickname`,`created_at`) VALUES (?,?,?)",
+			value:   []interface{}{"a", defaultValue{}, Now},
+		},
+		{
+			builder: InsertInto("t").Record(&taggedRecord{Name: "a", CreatedAt: created, UpdatedAt: created}).
+				Record(&taggedRecord{ID: 2, Name: "b", Nickname: "c", CreatedAt: created, UpdatedAt: created}),
+			query: "INSERT INTO `t` (`name`,`created_at`,`updated_at`,`id`,`nickname`) VALUES (?,?,?,?,?), (?,?,?,?,?)",
+			value: []interface{}{
+				"a", created, created, defaultValue{}, defaultValue{},
+				"b", created, created, int64(2), "c",
+			},
+		},
+		{
+			builder: InsertInto("t").Record(&taggedRecord{ID: 2, Name: "b", Nickname: "c", CreatedAt: created, UpdatedAt: created}).
+				Record(&taggedRecord{Name: "a", CreatedAt: created, UpdatedAt: created}),
+			query: "INSERT INTO `t` (`id`,`name`,`nickname`,`created_at`,`updated_at`) VALUES (?,?,?,?,?), (?,?,?,?,?)",
+			value: []interface{}{
+				int64(2), "b", "c", created, created,
+				defaultValue{}, "a", defaultValue{}, created, created,
+			},
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+		require.Equal(t, test.value, buf.Value())
+	}
+
+	r := &taggedRecord{}
+	b := InsertInto("t").Record(r)
+	require.Equal(t, &r.ID, b.RecordID)
+
+	query, err := InterpolateForDialect("?", []interface{}{defaultValue{}}, dialect.PostgreSQL)
+	require.NoError(t, err)
+	require.Equal(t, "DEFAULT", query)
+	_, err = InterpolateForDialect("?", []interface{}{defaultValue{}}, dialect.SQLite3)
+	require.Equal(t, ErrNotSupported, err)
+}
+
 func TestPostgresReturning(t *testing.T) {
 	sess := postgresSession
 	reset(t, sess)
@@ -2,6 +2,7 @@ package edb
 
 import (
 	"database/sql/driver"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -66,7 +67,7 @@ func (i *interpolator) interpolate(query string, value []interface{}, topLevel b
 		}
 
 		i.WriteString(query[:index])
-		if _, ok := value[valueIndex].([]byte); ok && i.IgnoreBinary {
+		if i.IgnoreBinary && isBindValue(value[valueIndex]) {
 			i.WriteString(i.Placeholder(i.N))
 			i.N++
 			i.WriteValue(value[valueIndex])
@@ -90,6 +91,16 @@ func (i *interpolator) interpolate(query string, value []interface{}, topLevel b
 	return nil
 }
 
+// isBindValue reports whether v is sent as a bound parameter
+// when binary values are ignored.
+func isBindValue(v interface{}) bool {
+	switch v.(type) {
+	case []byte, bindValuer:
+		return true
+	}
+	return false
+}
+
 var (
 	typeTime = reflect.TypeOf(time.Time{})
 )
@@ -119,6 +130,38 @@ func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error
 		return nil
 	}
 
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			i.WriteString("NULL")
+			return nil
+		}
+		i.WriteString(v.String())
+		return nil
+	case big.Int:
+		i.WriteString(v.String())
+		return nil
+	case *big.Rat:
+		if v == nil {
+			i.WriteString("NULL")
+			return nil
+		}
+		return i.encodeRat(v)
+	case big.Rat:
+		return i.encodeRat(&v)
+	case Decimal:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			i.WriteString("NULL")
+			return nil
+		}
+		s := v.String()
+		if !isNumeric(s) {
+			return ErrInvalidDecimal
+		}
+		i.WriteString(s)
+		return nil
+	}
+
 	if valuer, ok := value.(driver.Valuer); ok {
 		// get driver.Valuer's data
 		var err error
@@ -147,7 +190,7 @@ func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error
 		i.WriteString(strconv.FormatUint(v.Uint(), 10))
 		return nil
 	case reflect.Float32, reflect.Float64:
-		i.WriteString(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+		i.WriteString(strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()))
 		return nil
 	case reflect.Struct:
 		if v.Type() == typeTime {
@@ -185,3 +228,12 @@ func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error
 	}
 	return ErrNotSupported
 }
+
+func (i *interpolator) encodeRat(r *big.Rat) error {
+	s, err := ratString(r)
+	if err != nil {
+		return err
+	}
+	i.WriteString(s)
+	return nil
+}
@@ -3,6 +3,7 @@ package edb
 import (
 	"database/sql"
 	"reflect"
+	"sync"
 )
 
 // Iterator is an interface to iterate over the result of a sql query
@@ -15,15 +16,55 @@ type Iterator interface {
 	Err() error
 }
 
-// recordMeta holds target struct's reflect metadata cache
+// recordMeta holds target struct's reflect metadata cache for one query.
+//
+// The metadata of the destination type is shared with typeMeta, and the
+// field paths of the columns are cached by the tagStore.
 type recordMeta struct {
-	ptr           []interface{}
-	elemType      reflect.Type
+	*typeMeta
+	ptr      []interface{}
+	elemType reflect.Type
+	ts       *tagStore
+	columns  []string
+}
+
+// typeMeta is the part of recordMeta which only depends on
+// the type of the destination, so it is cached per type.
+type typeMeta struct {
 	isSlice       bool
 	isMap         bool
 	isMapOfSlices bool
-	ts            *tagStore
-	columns       []string
+	// rowType is the type of a row of a slice or map,
+	// and keyType is the key type of a map.
+	rowType reflect.Type
+	keyType reflect.Type
+}
+
+// typeMetaCache is map[reflect.Type]*typeMeta.
+var typeMetaCache sync.Map
+
+func getTypeMeta(t reflect.Type) *typeMeta {
+	if m, ok := typeMetaCache.Load(t); ok {
+		return m.(*typeMeta)
+	}
+	isScanner := reflect.PtrTo(t).Implements(typeScanner)
+	isSlice := t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && !isScanner
+	isMap := t.Kind() == reflect.Map && !isScanner
+	m := &typeMeta{
+		isSlice:       isSlice,
+		isMap:         isMap,
+		isMapOfSlices: isMap && t.Elem().Kind() == reflect.Slice && t.Elem().Elem().Kind() != reflect.Uint8,
+	}
+	if m.isMapOfSlices {
+		m.rowType = t.Elem().Elem()
+	} else if isSlice || isMap {
+		m.rowType = t.Elem()
+	}
+	if isMap {
+		m.keyType = t.Key()
+	}
+	typeMetaCache.Store(t, m)
+	return m
 }
 
 func (m *recordMeta) scan(rows *sql.Rows, value interface{}) (err error) {
@@ -34,13 +75,12 @@ func (m *recordMeta) scan(rows *sql.Rows, value interface{}) (err error) {
 	} else {
 		v = reflect.ValueOf(value)
 	}
+	v = v.Elem()
 
 	if m.elemType != nil {
 		elem = reflectAlloc(m.elemType)
-	} else if m.isMapOfSlices {
-		elem = reflectAlloc(v.Type().Elem().Elem())
-	} else if m.isSlice || m.isMap {
-		elem = reflectAlloc(v.Type().Elem())
+	} else if m.rowType != nil {
+		elem = reflectAlloc(m.rowType)
 	} else {
 		elem = v
 	}
@@ -50,7 +90,7 @@ func (m *recordMeta) scan(rows *sql.Rows, value interface{}) (err error) {
 		if err != nil {
 			return
 		}
-		keyElem = reflectAlloc(v.Type().Key())
+		keyElem = reflectAlloc(m.keyType)
 		err = m.ts.findPtr(keyElem, m.columns[:1], m.ptr[:1])
 		if err != nil {
 			return
@@ -108,23 +148,18 @@ func newRecordMeta(column []string, value interface{}) (meta *recordMeta, err er
 		return nil, ErrInvalidPointer
 	}
 	v = v.Elem()
-	isScanner := v.Addr().Type().Implements(typeScanner)
-	isSlice := v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !isScanner
-	isMap := v.Kind() == reflect.Map && !isScanner
-	isMapOfSlices := isMap && v.Type().Elem().Kind() == reflect.Slice && v.Type().Elem().Elem().Kind() != reflect.Uint8
-	if isMap {
+	tm := getTypeMeta(v.Type())
+	if tm.isMap {
 		v.Set(reflect.MakeMap(v.Type()))
 	}
 
 	s := newTagStore()
 	return &recordMeta{
-		elemType:      elemType,
-		isSlice:       isSlice,
-		isMap:         isMap,
-		isMapOfSlices: isMapOfSlices,
-		ts:            s,
-		ptr:           ptr,
-		columns:       column,
+		typeMeta: tm,
+		elemType: elemType,
+		ts:       s,
+		ptr:      ptr,
+		columns:  column,
 	}, nil
 }
 
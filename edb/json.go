@@ -0,0 +1,236 @@
+package edb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// JSON is a column stored as JSON and decoded into Data.
+//
+// It can be used as a struct field with Load and InsertStmt.Record,
+// or as a value of any condition.
+type JSON[T any] struct {
+	Data T
+}
+
+// NewJSON creates a JSON.
+func NewJSON[T any](v T) JSON[T] {
+	return JSON[T]{Data: v}
+}
+
+// Value implements the driver Valuer interface.
+func (j JSON[T]) Value() (driver.Value, error) {
+	b, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the Scanner interface.
+// SQL NULL resets Data to its zero value.
+func (j *JSON[T]) Scan(value interface{}) error {
+	var zero T
+	j.Data = zero
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, &j.Data)
+	case string:
+		return json.Unmarshal([]byte(v), &j.Data)
+	}
+	return ErrNotSupported
+}
+
+// MarshalJSON serializes Data as is.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON deserializes into Data.
+func (j *JSON[T]) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &j.Data)
+}
+
+// jsonPath splits a path like `$.a.b[0]` or `a.b` into keys.
+func jsonPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	var keys []string
+	for _, part := range strings.Split(path, ".") {
+		for {
+			i := strings.IndexByte(part, '[')
+			if i < 0 {
+				break
+			}
+			if i > 0 {
+				keys = append(keys, strings.Trim(part[:i], `"`))
+			}
+			j := strings.IndexByte(part, ']')
+			if j < i {
+				break
+			}
+			keys = append(keys, part[i+1:j])
+			part = part[j+1:]
+		}
+		if part != "" {
+			keys = append(keys, strings.Trim(part, `"`))
+		}
+	}
+	return keys
+}
+
+// isArrayIndex reports whether key is an array index of a json path.
+func isArrayIndex(key string) bool {
+	_, err := strconv.Atoi(key)
+	return err == nil
+}
+
+// buildJSONPath writes keys as a MySQL/SQLite/MSSQL json path like `$.a[0]`.
+func buildJSONPath(keys []string) string {
+	var buf strings.Builder
+	buf.WriteString("$")
+	for _, key := range keys {
+		if isArrayIndex(key) {
+			buf.WriteString("[" + key + "]")
+			continue
+		}
+		buf.WriteString(".")
+		if strings.IndexFunc(key, func(r rune) bool {
+			return !(r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+		}) >= 0 {
+			buf.WriteString(strconv.Quote(key))
+		} else {
+			buf.WriteString(key)
+		}
+	}
+	return buf.String()
+}
+
+// JSONExtract extracts the value at path of a JSON column as text.
+//
+// path is written like `$.a.b[0]`. It renders `->`/`->>` on PostgreSQL,
+// JSON_UNQUOTE(JSON_EXTRACT()) on MySQL, json_extract on SQLite, and
+// JSON_VALUE on MSSQL.
+//
+// It is an expression, so it can be compared with Expr:
+//
+//	Where(Expr("? = ?", JSONExtract("attrs", "$.color"), "red"))
+func JSONExtract(column, path string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		keys := jsonPath(path)
+		switch d {
+		case dialect.PostgreSQL:
+			buf.WriteString(d.QuoteIdent(column))
+			for i, key := range keys {
+				if i == len(keys)-1 {
+					buf.WriteString("->>")
+				} else {
+					buf.WriteString("->")
+				}
+				if isArrayIndex(key) {
+					buf.WriteString(key)
+				} else {
+					buf.WriteString(d.EncodeString(key))
+				}
+			}
+		case dialect.MySQL:
+			buf.WriteString("JSON_UNQUOTE(JSON_EXTRACT(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", ")
+			buf.WriteString(d.EncodeString(buildJSONPath(keys)))
+			buf.WriteString("))")
+		case dialect.SQLite3:
+			buf.WriteString("json_extract(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", ")
+			buf.WriteString(d.EncodeString(buildJSONPath(keys)))
+			buf.WriteString(")")
+		case dialect.MSSQL:
+			buf.WriteString("JSON_VALUE(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", ")
+			buf.WriteString(d.EncodeString(buildJSONPath(keys)))
+			buf.WriteString(")")
+		default:
+			return ErrNotSupported
+		}
+		return nil
+	})
+}
+
+// JSONContains checks that a JSON column contains value.
+//
+// It renders `@>` on PostgreSQL (the column must be jsonb) and
+// JSON_CONTAINS on MySQL. SQLite only supports a scalar value,
+// which is looked up in a JSON array with json_each.
+func JSONContains(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		switch d {
+		case dialect.PostgreSQL:
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(" @> ")
+			buf.WriteString(d.EncodeString(string(b)))
+		case dialect.MySQL:
+			buf.WriteString("JSON_CONTAINS(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", ")
+			buf.WriteString(d.EncodeString(string(b)))
+			buf.WriteString(")")
+		case dialect.SQLite3:
+			b = bytes.TrimSpace(b)
+			if len(b) > 0 && (b[0] == '{' || b[0] == '[') {
+				return ErrNotSupported
+			}
+			buf.WriteString("EXISTS (SELECT 1 FROM json_each(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(") WHERE json_each.value = ")
+			buf.WriteString(placeholder)
+			buf.WriteString(")")
+			buf.WriteValue(value)
+		default:
+			return ErrNotSupported
+		}
+		return nil
+	})
+}
+
+// JSONHasKey checks that a JSON object column has the top-level key.
+//
+// It renders `?` on PostgreSQL, JSON_CONTAINS_PATH on MySQL and json_type
+// on SQLite.
+func JSONHasKey(column, key string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		switch d {
+		case dialect.PostgreSQL:
+			buf.WriteString(d.QuoteIdent(column))
+			// escape placeholder by repeating it twice
+			buf.WriteString(" ?? ")
+			buf.WriteString(d.EncodeString(key))
+		case dialect.MySQL:
+			buf.WriteString("JSON_CONTAINS_PATH(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", 'one', ")
+			buf.WriteString(d.EncodeString(buildJSONPath([]string{key})))
+			buf.WriteString(")")
+		case dialect.SQLite3:
+			buf.WriteString("json_type(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(", ")
+			buf.WriteString(d.EncodeString(buildJSONPath([]string{key})))
+			buf.WriteString(") IS NOT NULL")
+		default:
+			return ErrNotSupported
+		}
+		return nil
+	})
+}
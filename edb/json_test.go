@@ -0,0 +1,143 @@
+package edb
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonAttrs struct {
+	Color string   `json:"color"`
+	Tags  []string `json:"tags"`
+}
+
+func TestJSONValuerScanner(t *testing.T) {
+	j := NewJSON(jsonAttrs{Color: "red", Tags: []string{"a"}})
+	v, err := j.Value()
+	require.NoError(t, err)
+	require.Equal(t, `{"color":"red","tags":["a"]}`, v)
+
+	var scanned JSON[jsonAttrs]
+	require.NoError(t, scanned.Scan([]byte(`{"color":"blue"}`)))
+	require.Equal(t, "blue", scanned.Data.Color)
+	require.NoError(t, scanned.Scan(nil))
+	require.Equal(t, jsonAttrs{}, scanned.Data)
+
+	b, err := json.Marshal(struct{ Attrs JSON[map[string]int] }{NewJSON(map[string]int{"a": 1})})
+	require.NoError(t, err)
+	require.Equal(t, `{"Attrs":{"a":1}}`, string(b))
+
+	query, err := InterpolateForDialect("?", []interface{}{j}, dialect.MySQL)
+	require.NoError(t, err)
+	require.Equal(t, `'{\"color\":\"red\",\"tags\":[\"a\"]}'`, query)
+}
+
+func TestJSONCondition(t *testing.T) {
+	for _, test := range []struct {
+		cond  Builder
+		d     Dialect
+		query string
+	}{
+		{
+			cond:  Expr("? = ?", JSONExtract("attrs", "$.color"), "red"),
+			d:     dialect.MySQL,
+			query: "JSON_UNQUOTE(JSON_EXTRACT(`attrs`, '$.color')) = 'red'",
+		},
+		{
+			cond:  Expr("? = ?", JSONExtract("attrs", "$.size.width"), "1"),
+			d:     dialect.PostgreSQL,
+			query: `"attrs"->'size'->>'width' = '1'`,
+		},
+		{
+			cond:  JSONExtract("attrs", "tags[0]"),
+			d:     dialect.PostgreSQL,
+			query: `"attrs"->'tags'->>0`,
+		},
+		{
+			cond:  JSONExtract("attrs", "$.tags[0]"),
+			d:     dialect.SQLite3,
+			query: `json_extract("attrs", '$.tags[0]')`,
+		},
+		{
+			cond:  JSONExtract("attrs", "$.color"),
+			d:     dialect.MSSQL,
+			query: `JSON_VALUE("attrs", '$.color')`,
+		},
+		{
+			cond:  JSONContains("attrs", map[string]string{"color": "red"}),
+			d:     dialect.PostgreSQL,
+			query: `"attrs" @> '{"color":"red"}'`,
+		},
+		{
+			cond:  JSONContains("tags", "a"),
+			d:     dialect.MySQL,
+			query: "JSON_CONTAINS(`tags`, '\\\"a\\\"')",
+		},
+		{
+			cond:  JSONContains("tags", "a"),
+			d:     dialect.SQLite3,
+			query: `EXISTS (SELECT 1 FROM json_each("tags") WHERE json_each.value = 'a')`,
+		},
+		{
+			cond:  JSONHasKey("attrs", "color"),
+			d:     dialect.PostgreSQL,
+			query: `"attrs" ? 'color'`,
+		},
+		{
+			cond:  JSONHasKey("attrs", "first name"),
+			d:     dialect.MySQL,
+			query: "JSON_CONTAINS_PATH(`attrs`, 'one', '$.\\\"first name\\\"')",
+		},
+		{
+			cond:  JSONHasKey("attrs", "color"),
+			d:     dialect.SQLite3,
+			query: `json_type("attrs", '$.color') IS NOT NULL`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.cond.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	err := JSONContains("attrs", map[string]string{"color": "red"}).Build(dialect.SQLite3, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSQLite3JSON(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.CreateTable("json_items").Columns(
+		Col("id", TypeInt).AutoIncrement(),
+		Col("attrs", TypeText),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	type item struct {
+		ID    int64
+		Attrs JSON[jsonAttrs]
+	}
+	for _, v := range []*item{
+		{Attrs: NewJSON(jsonAttrs{Color: "red", Tags: []string{"a", "b"}})},
+		{Attrs: NewJSON(jsonAttrs{Color: "blue", Tags: []string{"c"}})},
+	} {
+		_, err := sess.InsertInto("json_items").Columns("attrs").Record(v).ExecContext(ctx)
+		require.NoError(t, err)
+	}
+
+	// json functions need go-sqlite3 built with the sqlite_json tag,
+	// so only the round trip of JSON is checked here.
+	var items []item
+	_, err = sess.Select("*").From("json_items").OrderAsc("id").LoadContext(ctx, &items)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "red", items[0].Attrs.Data.Color)
+	require.Equal(t, []string{"a", "b"}, items[0].Attrs.Data.Tags)
+	require.Equal(t, []string{"c"}, items[1].Attrs.Data.Tags)
+}
@@ -28,6 +28,10 @@ func InterfaceLoader(value interface{}, concreteType interface{}) interface{} {
 //
 // 4. map of slice; like map, values with the same key are
 // collected with a slice.
+//
+// Struct fields are matched with columns by `db` tag or NameMapping.
+// Fields of a nested struct can be loaded from a join with a prefix
+// like `db:"user,prefix=user_"`, or with columns aliased as "user.id".
 func Load(rows *sql.Rows, value interface{}) (int, error) {
 	defer rows.Close()
 
@@ -35,90 +39,19 @@ func Load(rows *sql.Rows, value interface{}) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	ptr := make([]interface{}, len(column))
-
-	var v reflect.Value
-	var elemType reflect.Type
-
-	if il, ok := value.(interfaceLoader); ok {
-		v = reflect.ValueOf(il.v)
-		elemType = il.typ
-	} else {
-		v = reflect.ValueOf(value)
-	}
-
-	if v.Kind() != reflect.Ptr || v.IsNil() {
-		return 0, ErrInvalidPointer
-	}
-	v = v.Elem()
-	isScanner := v.Addr().Type().Implements(typeScanner)
-	isSlice := v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !isScanner
-	isMap := v.Kind() == reflect.Map && !isScanner
-	isMapOfSlices := isMap && v.Type().Elem().Kind() == reflect.Slice && v.Type().Elem().Elem().Kind() != reflect.Uint8
-	if isMap {
-		v.Set(reflect.MakeMap(v.Type()))
+	meta, err := newRecordMeta(column, value)
+	if err != nil {
+		return 0, err
 	}
 
-	s := newTagStore()
 	count := 0
 	for rows.Next() {
-		var elem, keyElem reflect.Value
-
-		if elemType != nil {
-			elem = reflectAlloc(elemType)
-		} else if isMapOfSlices {
-			elem = reflectAlloc(v.Type().Elem().Elem())
-		} else if isSlice || isMap {
-			elem = reflectAlloc(v.Type().Elem())
-		} else {
-			elem = v
-		}
-
-		if isMap {
-			err := s.findPtr(elem, column[1:], ptr[1:])
-			if err != nil {
-				return 0, err
-			}
-			keyElem = reflectAlloc(v.Type().Key())
-			err = s.findPtr(keyElem, column[:1], ptr[:1])
-			if err != nil {
-				return 0, err
-			}
-		} else {
-			err := s.findPtr(elem, column, ptr)
-			if err != nil {
-				return 0, err
-			}
-		}
-
-		// Before scanning, set nil pointer to dummy dest.
-		// After that, reset pointers to nil for the next batch.
-		for i := range ptr {
-			if ptr[i] == nil {
-				ptr[i] = dummyDest
-			}
-		}
-		err = rows.Scan(ptr...)
+		err = meta.scan(rows, value)
 		if err != nil {
 			return 0, err
 		}
-		for i := range ptr {
-			ptr[i] = nil
-		}
-
 		count++
-
-		if isSlice {
-			v.Set(reflect.Append(v, elem))
-		} else if isMapOfSlices {
-			s := v.MapIndex(keyElem)
-			if !s.IsValid() {
-				s = reflect.Zero(v.Type().Elem())
-			}
-			v.SetMapIndex(keyElem, reflect.Append(s, elem))
-		} else if isMap {
-			v.SetMapIndex(keyElem, elem)
-		} else {
+		if !meta.isSlice && !meta.isMap {
 			break
 		}
 	}
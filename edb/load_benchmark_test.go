@@ -3,7 +3,9 @@ package edb
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
@@ -74,3 +76,32 @@ func BenchmarkLoadValues(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkFindPtr(b *testing.B) {
+	type user struct {
+		ID   int64
+		Name string
+	}
+	type order struct {
+		ID        int64
+		Title     string
+		Body      string
+		CreatedAt time.Time
+		User      user `db:"user,prefix=user_"`
+	}
+	column := []string{"id", "title", "body", "created_at", "user_id", "user_name"}
+	ptr := make([]interface{}, len(column))
+
+	b.ReportAllocs()
+	s := newTagStore()
+	for i := 0; i < b.N; i++ {
+		var o order
+		err := s.findPtr(reflect.ValueOf(&o).Elem(), column, ptr)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i := range ptr {
+			ptr[i] = nil
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Package migrate applies versioned SQL migrations with edb.
+//
+// Migrations are read from an fs.FS as pairs of files named
+// `<version>_<name>.up.sql` and `<version>_<name>.down.sql`, for example
+// `0001_create_users.up.sql`. The down file is optional.
+//
+// Applied versions are recorded in the schema_migrations table. While
+// migrating, an advisory lock is held (GET_LOCK on MySQL, pg_advisory_lock on
+// PostgreSQL) so that only one process migrates at a time.
+//
+// Each migration runs inside an edb Tx, except on MySQL where DDL commits
+// implicitly. A migration file is executed as a single statement, so
+// multi-statement files on MySQL need multiStatements=true in the DSN.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ego-plugin/store/edb"
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// package errors
+var (
+	ErrInvalidFilename  = errors.New("migrate: invalid migration filename")
+	ErrDuplicateVersion = errors.New("migrate: duplicate migration version")
+	ErrMissingUp        = errors.New("migrate: missing up migration")
+	ErrMissingDown      = errors.New("migrate: missing down migration")
+	ErrNoApplied        = errors.New("migrate: no applied migration")
+)
+
+// DefaultTable is the table used to record applied migrations.
+const DefaultTable = "schema_migrations"
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration is applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt edb.NullTime
+}
+
+// Load reads migrations in dir from fsys, sorted by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		version, name, up, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		b, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := m[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: name}
+			m[version] = mig
+		} else if mig.Name != name {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateVersion, entry.Name())
+		}
+		if up {
+			mig.Up = string(b)
+		} else {
+			mig.Down = string(b)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(m))
+	for _, mig := range m {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMissingUp, mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func parseFilename(filename string) (version int64, name string, up bool, err error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		base, up = strings.TrimSuffix(base, ".up"), true
+	case strings.HasSuffix(base, ".down"):
+		base = strings.TrimSuffix(base, ".down")
+	default:
+		return 0, "", false, fmt.Errorf("%w: %s", ErrInvalidFilename, filename)
+	}
+	part := strings.SplitN(base, "_", 2)
+	version, err = strconv.ParseInt(part[0], 10, 64)
+	if err != nil {
+		return 0, "", false, fmt.Errorf("%w: %s", ErrInvalidFilename, filename)
+	}
+	if len(part) == 2 {
+		name = part[1]
+	}
+	return version, name, up, nil
+}
+
+// Migrator applies migrations to a Session.
+type Migrator struct {
+	sess       *edb.Session
+	migrations []Migration
+
+	// Table records applied versions, DefaultTable by default.
+	Table string
+	// LockName identifies the advisory lock, Table by default.
+	LockName string
+}
+
+// New creates a Migrator with migrations in the root of fsys.
+func New(sess *edb.Session, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		sess:       sess,
+		migrations: migrations,
+		Table:      DefaultTable,
+	}, nil
+}
+
+// Migrations returns all known migrations sorted by version.
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status lists all migrations and whether they are applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	err := m.createTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		appliedAt, ok := applied[mig.Version]
+		status[i] = Status{
+			Migration: mig,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+	return status, nil
+}
+
+// Plan lists migrations that Up would apply.
+func (m *Migrator) Plan(ctx context.Context) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range status {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies all pending migrations in version order.
+// It returns the migrations applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		pending, err := m.Plan(ctx)
+		if err != nil {
+			return err
+		}
+		for _, mig := range pending {
+			err := m.run(ctx, mig, mig.Up, func(r edb.SessionRunner) error {
+				_, err := r.InsertInto(m.Table).
+					Pair("version", mig.Version).
+					Pair("name", mig.Name).
+					Pair("applied_at", time.Now().UTC()).
+					ExecContext(ctx)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the most recently applied migration.
+// It returns the migration reverted.
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	var mig Migration
+	err := m.withLock(ctx, func() error {
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		last := -1
+		for i, s := range status {
+			if s.Applied {
+				last = i
+			}
+		}
+		if last < 0 {
+			return ErrNoApplied
+		}
+		mig = status[last].Migration
+		if mig.Down == "" {
+			return fmt.Errorf("%w: %d_%s", ErrMissingDown, mig.Version, mig.Name)
+		}
+		return m.run(ctx, mig, mig.Down, func(r edb.SessionRunner) error {
+			_, err := r.DeleteFrom(m.Table).
+				Where(edb.Eq("version", mig.Version)).
+				ExecContext(ctx)
+			return err
+		})
+	})
+	return mig, err
+}
+
+// run executes query and record in one transaction if the dialect allows
+// DDL in transactions.
+func (m *Migrator) run(ctx context.Context, mig Migration, query string, record func(edb.SessionRunner) error) error {
+	m.sess.EventKv("dbr.migrate", map[string]string{
+		"version": strconv.FormatInt(mig.Version, 10),
+		"name":    mig.Name,
+	})
+
+	if m.sess.Dialect == dialect.MySQL {
+		_, err := m.sess.ExecContext(ctx, query)
+		if err != nil {
+			return m.sess.EventErrKv("dbr.migrate.exec", err, map[string]string{
+				"version": strconv.FormatInt(mig.Version, 10),
+			})
+		}
+		return record(m.sess)
+	}
+
+	tx, err := m.sess.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		return m.sess.EventErrKv("dbr.migrate.exec", err, map[string]string{
+			"version": strconv.FormatInt(mig.Version, 10),
+		})
+	}
+	err = record(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) createTable(ctx context.Context) error {
+	_, err := m.sess.CreateTable(m.Table).IfNotExists().Columns(
+		edb.Col("version", edb.TypeBigInt).PrimaryKey(),
+		edb.Col("name", edb.TypeVarchar(255)).NotNull(),
+		edb.Col("applied_at", edb.TypeTimestamp).NotNull(),
+	).ExecContext(ctx)
+	return err
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]edb.NullTime, error) {
+	var rows []struct {
+		Version   int64
+		AppliedAt edb.NullTime
+	}
+	_, err := m.sess.Select("version", "applied_at").From(m.Table).LoadContext(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]edb.NullTime, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// withLock runs fn while holding the advisory lock.
+// Dialects without advisory locks run fn directly.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	var lock, unlock string
+	var key interface{}
+	name := m.LockName
+	if name == "" {
+		name = m.Table
+	}
+	switch m.sess.Dialect {
+	case dialect.MySQL:
+		lock, unlock, key = "SELECT GET_LOCK(?, -1)", "SELECT RELEASE_LOCK(?)", name
+	case dialect.PostgreSQL:
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		lock, unlock, key = "SELECT pg_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", int64(h.Sum64())
+	default:
+		return fn()
+	}
+
+	// advisory locks belong to a database session, so hold one connection
+	conn, err := m.sess.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.sess.Dialect == dialect.MySQL {
+		// GET_LOCK returns 1 on success, 0 or NULL otherwise
+		var ok sql.NullInt64
+		err = conn.QueryRowContext(ctx, lock, key).Scan(&ok)
+		if err == nil && ok.Int64 != 1 {
+			err = fmt.Errorf("migrate: failed to acquire lock %q", name)
+		}
+	} else {
+		_, err = conn.ExecContext(ctx, lock, key)
+	}
+	if err != nil {
+		return m.sess.EventErr("dbr.migrate.lock", err)
+	}
+	defer conn.ExecContext(context.Background(), unlock, key)
+
+	return fn()
+}
@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ego-plugin/store/edb"
+)
+
+var testFS = fstest.MapFS{
+	"0001_create_people.up.sql":   {Data: []byte(`CREATE TABLE people (id integer PRIMARY KEY, name varchar(255))`)},
+	"0001_create_people.down.sql": {Data: []byte(`DROP TABLE people`)},
+	"0002_index_name.up.sql":      {Data: []byte(`CREATE INDEX people_name ON people (name)`)},
+	"0002_index_name.down.sql":    {Data: []byte(`DROP INDEX people_name`)},
+	"README.md":                   {Data: []byte(`ignored`)},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS, ".")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, int64(1), migrations[0].Version)
+	require.Equal(t, "create_people", migrations[0].Name)
+	require.Equal(t, `DROP TABLE people`, migrations[0].Down)
+	require.Equal(t, int64(2), migrations[1].Version)
+
+	_, err = Load(fstest.MapFS{"create.up.sql": {}}, ".")
+	require.True(t, errors.Is(err, ErrInvalidFilename))
+
+	_, err = Load(fstest.MapFS{"0001_a.down.sql": {}}, ".")
+	require.True(t, errors.Is(err, ErrMissingUp))
+
+	_, err = Load(fstest.MapFS{"0001_a.up.sql": {}, "0001_b.up.sql": {}}, ".")
+	require.True(t, errors.Is(err, ErrDuplicateVersion))
+}
+
+func TestMigrator(t *testing.T) {
+	conn, err := edb.Open("sqlite3", ":memory:", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+	sess := conn.NewSession(nil)
+	ctx := context.Background()
+
+	m, err := New(sess, testFS)
+	require.NoError(t, err)
+
+	plan, err := m.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, plan, 2)
+
+	done, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+
+	_, err = sess.InsertInto("people").Pair("name", "a").Exec()
+	require.NoError(t, err)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, status[0].Applied)
+	require.True(t, status[1].Applied)
+	require.True(t, status[1].AppliedAt.Valid)
+
+	done, err = m.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, done, 0)
+
+	mig, err := m.Down(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), mig.Version)
+
+	plan, err = m.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	require.Equal(t, int64(2), plan[0].Version)
+
+	_, err = m.Down(ctx)
+	require.NoError(t, err)
+	_, err = m.Down(ctx)
+	require.Equal(t, ErrNoApplied, err)
+}
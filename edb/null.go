@@ -0,0 +1,71 @@
+package edb
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Null is a type that can be null or a T.
+//
+// It covers types without a hand-written Null type, like int32, uint64,
+// decimals, uuids or custom enums, with the same JSON null semantics.
+type Null[T any] struct {
+	sql.Null[T]
+}
+
+// NewNull creates a valid Null.
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{sql.Null[T]{V: v, Valid: true}}
+}
+
+// NewNullPtr creates a Null from a pointer. A nil pointer is null.
+func NewNullPtr[T any](v *T) Null[T] {
+	if v == nil {
+		return Null[T]{}
+	}
+	return NewNull(*v)
+}
+
+// Ptr returns a pointer to V, or nil if n is null.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.V
+}
+
+// Value implements the driver Valuer interface.
+// V is converted with driver.DefaultParameterConverter, so named types and
+// types implementing driver.Valuer are supported.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// MarshalJSON correctly serializes a Null to JSON.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if n.Valid {
+		return json.Marshal(n.V)
+	}
+	return nullString, nil
+}
+
+// UnmarshalJSON correctly deserializes a Null from JSON.
+func (n *Null[T]) UnmarshalJSON(b []byte) error {
+	var zero T
+	n.V = zero
+	if bytes.Equal(bytes.TrimSpace(b), nullString) {
+		n.Valid = false
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.V); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
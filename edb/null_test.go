@@ -0,0 +1,95 @@
+package edb
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus string
+
+func TestNullValuerScanner(t *testing.T) {
+	v, err := NewNull(int32(7)).Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), v)
+
+	v, err = NewNull(testStatus("active")).Value()
+	require.NoError(t, err)
+	require.Equal(t, "active", v)
+
+	v, err = Null[int32]{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	var n Null[uint64]
+	require.NoError(t, n.Scan([]byte("42")))
+	require.Equal(t, NewNull(uint64(42)), n)
+	require.NoError(t, n.Scan(nil))
+	require.Equal(t, Null[uint64]{}, n)
+
+	var status Null[testStatus]
+	require.NoError(t, status.Scan("active"))
+	require.Equal(t, NewNull(testStatus("active")), status)
+
+	x := int32(1)
+	require.Equal(t, NewNull(x), NewNullPtr(&x))
+	require.Equal(t, Null[int32]{}, NewNullPtr[int32](nil))
+	require.Equal(t, &x, NewNull(x).Ptr())
+	require.Nil(t, Null[int32]{}.Ptr())
+
+	query, err := InterpolateForDialect("? ?", []interface{}{NewNull(int32(1)), Null[string]{}}, dialect.MySQL)
+	require.NoError(t, err)
+	require.Equal(t, "1 NULL", query)
+}
+
+func TestNullJSON(t *testing.T) {
+	type record struct {
+		A Null[int32]
+		B Null[testStatus]
+	}
+	b, err := json.Marshal(record{A: NewNull(int32(1))})
+	require.NoError(t, err)
+	require.Equal(t, `{"A":1,"B":null}`, string(b))
+
+	var r record
+	require.NoError(t, json.Unmarshal([]byte(`{"A":null,"B":"active"}`), &r))
+	require.Equal(t, record{B: NewNull(testStatus("active"))}, r)
+
+	require.Error(t, json.Unmarshal([]byte(`{"A":"x"}`), &r))
+}
+
+func TestSQLite3Null(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.CreateTable("null_items").Columns(
+		Col("id", TypeInt).AutoIncrement(),
+		Col("count", TypeInt),
+		Col("size", TypeBigInt),
+		Col("status", TypeText),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	type item struct {
+		ID     int64
+		Count  Null[int32]
+		Size   Null[uint64]
+		Status Null[testStatus]
+	}
+	in := []*item{
+		{Count: NewNull(int32(1)), Size: NewNull(uint64(2)), Status: NewNull(testStatus("active"))},
+		{},
+	}
+	for _, v := range in {
+		_, err := sess.InsertInto("null_items").Columns("count", "size", "status").Record(v).ExecContext(ctx)
+		require.NoError(t, err)
+	}
+
+	var items []*item
+	_, err = sess.Select("*").From("null_items").OrderAsc("id").LoadContext(ctx, &items)
+	require.NoError(t, err)
+	require.Equal(t, in, items)
+}
@@ -1,5 +1,7 @@
 package edb
 
+import "strings"
+
 type direction bool
 
 // orderby directions
@@ -9,16 +11,83 @@ const (
 	desc           = true
 )
 
+// identBuilder is a Builder of identifiers written as strings,
+// which are quoted in safe identifier mode.
+type identBuilder interface {
+	Builder
+	buildIdent(d Dialect, buf Buffer) error
+}
+
+// orderItem is a column of ORDER BY.
+type orderItem struct {
+	column string
+	// dir is empty if the direction is written in column
+	dir string
+}
+
 func order(column string, dir direction) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		// FIXME: no quote ident
-		buf.WriteString(column)
-		switch dir {
-		case asc:
-			buf.WriteString(" ASC")
-		case desc:
-			buf.WriteString(" DESC")
+	switch dir {
+	case desc:
+		return orderItem{column: column, dir: " DESC"}
+	default:
+		return orderItem{column: column, dir: " ASC"}
+	}
+}
+
+func (o orderItem) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(o.column)
+	buf.WriteString(o.dir)
+	return nil
+}
+
+func (o orderItem) buildIdent(d Dialect, buf Buffer) error {
+	column, dir := o.column, o.dir
+	if dir == "" {
+		part := strings.Fields(column)
+		if len(part) == 2 && (strings.EqualFold(part[1], "ASC") || strings.EqualFold(part[1], "DESC")) {
+			column, dir = part[0], " "+strings.ToUpper(part[1])
 		}
+	}
+	s, err := quoteIdentPath(d, column)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(s)
+	buf.WriteString(dir)
+	return nil
+}
+
+// buildOrder writes ORDER BY if order is not empty.
+func buildOrder(d Dialect, buf Buffer, order []Builder) error {
+	if len(order) == 0 {
 		return nil
-	})
+	}
+	buf.WriteString(" ORDER BY ")
+	for i, o := range order {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		err := o.Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupItem is a column of GROUP BY.
+type groupItem string
+
+func (g groupItem) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(string(g))
+	return nil
+}
+
+func (g groupItem) buildIdent(d Dialect, buf Buffer) error {
+	s, err := quoteIdentPath(d, string(g))
+	if err != nil {
+		return err
+	}
+	buf.WriteString(s)
+	return nil
 }
@@ -0,0 +1,62 @@
+package edb
+
+import (
+	"context"
+	"sync"
+)
+
+// Page describes a page loaded by LoadPage.
+type Page struct {
+	// Total is the number of rows of all pages.
+	Total int64
+	// Page starts from 1.
+	Page uint64
+	// PerPage is 0 if the statement has no limit.
+	PerPage uint64
+	HasNext bool
+}
+
+// LoadPage loads the page of the statement set by Paginate into value,
+// and counts the rows of all pages with Count.
+//
+// On a Session, the count query runs concurrently on another connection
+// of the pool. On a Tx, both queries run one after another.
+func (b *SelectStmt) LoadPage(ctx context.Context, value interface{}) (Page, error) {
+	var (
+		total    int64
+		countErr error
+		wg       sync.WaitGroup
+	)
+	if _, ok := b.runner.(*Session); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			total, countErr = b.Count(ctx)
+		}()
+	} else {
+		total, countErr = b.Count(ctx)
+		if countErr != nil {
+			return Page{}, countErr
+		}
+	}
+	n, err := b.LoadContext(ctx, value)
+	wg.Wait()
+	if err != nil {
+		return Page{}, err
+	}
+	if countErr != nil {
+		return Page{}, countErr
+	}
+
+	p := Page{Total: total, Page: 1}
+	offset := b.OffsetCount
+	if offset < 0 {
+		offset = 0
+	}
+	if b.LimitCount > 0 {
+		p.PerPage = uint64(b.LimitCount)
+		p.Page = uint64(offset)/p.PerPage + 1
+	}
+	p.HasNext = offset+int64(n) < total
+	return p, nil
+}
@@ -0,0 +1,44 @@
+package edb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite3LoadPage(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.Exec(`CREATE TABLE people (id integer, name text)`)
+	require.NoError(t, err)
+	insert := sess.InsertInto("people").Columns("id", "name")
+	for i := 1; i <= 5; i++ {
+		insert.Values(i, "a")
+	}
+	_, err = insert.ExecContext(ctx)
+	require.NoError(t, err)
+
+	var ids []int64
+	p, err := sess.Select("id").From("people").OrderAsc("id").Paginate(2, 2).LoadPage(ctx, &ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 4}, ids)
+	require.Equal(t, Page{Total: 5, Page: 2, PerPage: 2, HasNext: true}, p)
+
+	tx, err := sess.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	ids = nil
+	p, err = tx.Select("id").From("people").OrderAsc("id").Paginate(3, 2).LoadPage(ctx, &ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, ids)
+	require.Equal(t, Page{Total: 5, Page: 3, PerPage: 2}, p)
+
+	ids = nil
+	p, err = tx.Select("id").From("people").Where(Gt("id", 3)).LoadPage(ctx, &ids)
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 5}, ids)
+	require.Equal(t, Page{Total: 2, Page: 1}, p)
+}
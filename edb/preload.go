@@ -0,0 +1,153 @@
+package edb
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// preload is a one-to-many relation loaded after the parent rows.
+type preload struct {
+	field      string
+	child      *SelectStmt
+	foreignKey string
+	key        string
+}
+
+// Preload loads the rows of child into the slice field of each loaded
+// struct, where the column foreignKey of child equals the column key of
+// the struct.
+//
+//	sess.Select("*").From("users").
+//		Preload("Orders", edb.Select("*").From("orders"), "user_id", "id").
+//		Load(&users)
+//
+// After Load or LoadOne, the children of all loaded structs are selected
+// with one `foreignKey IN (...)` query, and grouped with Load's map of
+// slices support. child is copied, and runs with the runner, dialect,
+// soft delete scope and safe identifier mode of b.
+func (b *SelectStmt) Preload(field string, child *SelectStmt, foreignKey, key string) *SelectStmt {
+	b.preloads = append(b.preloads, preload{
+		field:      field,
+		child:      child,
+		foreignKey: foreignKey,
+		key:        key,
+	})
+	return b
+}
+
+// tableAlias returns the alias of a table written as
+// `users`, `users u` or `users AS u`.
+func tableAlias(table string) string {
+	part := strings.Fields(table)
+	if len(part) == 0 {
+		return ""
+	}
+	return part[len(part)-1]
+}
+
+// collectStructs returns addressable structs in v,
+// which can be a struct, a pointer or a slice of them.
+func collectStructs(v reflect.Value) []reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return collectStructs(v.Elem())
+	case reflect.Slice:
+		var l []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			l = append(l, collectStructs(v.Index(i))...)
+		}
+		return l
+	case reflect.Struct:
+		if v.CanAddr() {
+			return []reflect.Value{v}
+		}
+	}
+	return nil
+}
+
+func (b *SelectStmt) loadPreloads(ctx context.Context, value interface{}) error {
+	if len(b.preloads) == 0 {
+		return nil
+	}
+	if il, ok := value.(interfaceLoader); ok {
+		value = il.v
+	}
+	parents := collectStructs(reflect.ValueOf(value))
+	if len(parents) == 0 {
+		return nil
+	}
+	for _, p := range b.preloads {
+		err := b.loadPreload(ctx, p, parents)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *SelectStmt) loadPreload(ctx context.Context, p preload, parents []reflect.Value) error {
+	s := newTagStore()
+	keys := make([]reflect.Value, len(parents))
+	fields := make([]reflect.Value, len(parents))
+	var keyValue []interface{}
+	seen := make(map[interface{}]bool)
+	for i, parent := range parents {
+		found := make([]interface{}, 1)
+		s.findValueByName(parent, []string{p.key}, found, false)
+		field := parent.FieldByName(p.field)
+		if found[0] == nil || field.Kind() != reflect.Slice || !field.CanSet() {
+			return ErrInvalidPreload
+		}
+		keys[i] = found[0].(reflect.Value)
+		fields[i] = field
+		if k := keys[i].Interface(); !seen[k] {
+			seen[k] = true
+			keyValue = append(keyValue, k)
+		}
+	}
+
+	q := *p.child
+	q.runner = b.runner
+	q.EventReceiver = b.EventReceiver
+	q.Dialect = b.Dialect
+	q.SoftDelete = b.SoftDelete
+	q.SafeIdent = b.SafeIdent
+	q.preloads = nil
+
+	// the first column is the key of Load's map of slices
+	foreignKey := p.foreignKey
+	alias := ""
+	if table, ok := q.Table.(string); ok {
+		alias = tableAlias(table)
+	}
+	if alias != "" && !strings.Contains(foreignKey, ".") {
+		foreignKey = alias + "." + foreignKey
+	}
+	q.Column = []interface{}{foreignKey}
+	for _, col := range p.child.Column {
+		if col == "*" && alias != "" {
+			// `SELECT user_id, *` is invalid on MySQL
+			col = alias + ".*"
+		}
+		q.Column = append(q.Column, col)
+	}
+	q.WhereCond = append(q.WhereCond[:len(q.WhereCond):len(q.WhereCond)], Eq(foreignKey, keyValue))
+
+	m := reflect.New(reflect.MapOf(keys[0].Type(), fields[0].Type()))
+	_, err := query(ctx, q.runner, q.EventReceiver, &q, q.Dialect, m.Interface())
+	if err != nil {
+		return err
+	}
+	for i := range parents {
+		children := m.Elem().MapIndex(keys[i])
+		if !children.IsValid() {
+			children = reflect.Zero(fields[i].Type())
+		}
+		fields[i].Set(children)
+	}
+	return nil
+}
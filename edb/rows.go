@@ -0,0 +1,138 @@
+package edb
+
+import (
+	"context"
+	"iter"
+)
+
+// Rows is a typed Iterator, which scans each row into a T.
+//
+//	rows, err := edb.Iterate[Person](ctx, stmt)
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//	for rows.Next() {
+//		p := rows.Value()
+//	}
+//	return rows.Err()
+type Rows[T any] struct {
+	it  Iterator
+	cur T
+	err error
+}
+
+// Iterate executes stmt and returns its Rows.
+func Iterate[T any](ctx context.Context, stmt *SelectStmt) (*Rows[T], error) {
+	it, err := stmt.IterateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Rows[T]{it: it}, nil
+}
+
+// Next scans the next row. It returns false at the end or on error.
+func (r *Rows[T]) Next() bool {
+	if r.err != nil || !r.it.Next() {
+		return false
+	}
+	var v T
+	r.err = r.it.Scan(&v)
+	if r.err != nil {
+		return false
+	}
+	r.cur = v
+	return true
+}
+
+// Value returns the row scanned by Next.
+func (r *Rows[T]) Value() T {
+	return r.cur
+}
+
+// Err returns the error encountered during iteration, if any.
+func (r *Rows[T]) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.it.Err()
+}
+
+// Close frees the resources of the query.
+func (r *Rows[T]) Close() error {
+	err := r.it.Close()
+	if r.err != nil {
+		return r.err
+	}
+	return err
+}
+
+// Seq returns an iterator over the rows for range-over-func.
+// The rows are closed when the loop ends, even on an early break.
+// An error ends the sequence with the zero T.
+func (r *Rows[T]) Seq() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer r.Close()
+		for r.Next() {
+			if !yield(r.cur, nil) {
+				return
+			}
+		}
+		if err := r.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Batches returns an iterator over slices of up to n rows, which is useful
+// for streaming exports. Each batch is a new slice.
+// If reading a row fails, the rows read before it are yielded with the
+// error, so the last batch may be partial or empty.
+// The rows are closed when the loop ends, even on an early break.
+func (r *Rows[T]) Batches(n int) iter.Seq2[[]T, error] {
+	if n < 1 {
+		n = 1
+	}
+	return func(yield func([]T, error) bool) {
+		defer r.Close()
+		batch := make([]T, 0, n)
+		for r.Next() {
+			batch = append(batch, r.cur)
+			if len(batch) < n {
+				continue
+			}
+			if !yield(batch, nil) {
+				return
+			}
+			batch = make([]T, 0, n)
+		}
+		if err := r.Err(); err != nil {
+			yield(batch, err)
+			return
+		}
+		if len(batch) > 0 {
+			yield(batch, nil)
+		}
+	}
+}
+
+// Seq executes stmt when the loop starts, and iterates over its rows
+// like Rows.Seq.
+//
+//	for p, err := range edb.Seq[Person](ctx, stmt) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func Seq[T any](ctx context.Context, stmt *SelectStmt) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		rows, err := Iterate[T](ctx, stmt)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		rows.Seq()(yield)
+	}
+}
@@ -0,0 +1,100 @@
+package edb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite3Rows(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	_, err := sess.Exec(`CREATE TABLE items (id integer, name text)`)
+	require.NoError(t, err)
+	for i := int64(1); i <= 5; i++ {
+		_, err = sess.InsertInto("items").Pair("id", i).Pair("name", "n").Exec()
+		require.NoError(t, err)
+	}
+
+	type item struct {
+		ID   int64
+		Name string
+	}
+	stmt := sess.Select("*").From("items").OrderAsc("id")
+
+	rows, err := Iterate[item](ctx, stmt)
+	require.NoError(t, err)
+	var ids []int64
+	for rows.Next() {
+		ids = append(ids, rows.Value().ID)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+
+	ids = nil
+	for v, err := range Seq[item](ctx, stmt) {
+		require.NoError(t, err)
+		ids = append(ids, v.ID)
+		if v.ID == 2 {
+			break
+		}
+	}
+	require.Equal(t, []int64{1, 2}, ids)
+
+	// the only connection is released by the early break
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	ids = nil
+	for id, err := range Seq[int64](timeoutCtx, sess.Select("id").From("items").OrderAsc("id")) {
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+
+	rows, err = Iterate[item](ctx, stmt)
+	require.NoError(t, err)
+	var sizes []int
+	for batch, err := range rows.Batches(2) {
+		require.NoError(t, err)
+		sizes = append(sizes, len(batch))
+	}
+	require.Equal(t, []int{2, 2, 1}, sizes)
+
+	for _, err := range Seq[item](ctx, sess.Select("*").From("missing")) {
+		require.Error(t, err)
+	}
+}
+
+func TestRowsBatchesError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.MySQL,
+	}
+	sess := conn.NewSession(nil)
+
+	readErr := errors.New("read failed")
+	mock.ExpectQuery("SELECT id FROM items").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3).RowError(2, readErr))
+
+	rows, err := Iterate[int64](context.Background(), sess.Select("id").From("items"))
+	require.NoError(t, err)
+	var batches [][]int64
+	var errs []error
+	for batch, err := range rows.Batches(5) {
+		batches = append(batches, batch)
+		errs = append(errs, err)
+	}
+	require.Equal(t, [][]int64{{1, 2}}, batches)
+	require.Equal(t, []error{readErr}, errs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
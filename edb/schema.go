@@ -0,0 +1,219 @@
+package edb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ego-plugin/store/edb/dialect"
+)
+
+// ColumnInfo describes a table column.
+type ColumnInfo struct {
+	Name     string
+	DataType string
+	Nullable bool
+	Default  NullString
+}
+
+// IndexInfo describes a table index.
+// Columns are listed in index order.
+type IndexInfo struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// ForeignKeyInfo describes a foreign key constraint.
+// Columns[i] references RefColumns[i] of RefTable.
+//
+// SQLite does not name foreign keys, the constraint id is used as Name.
+type ForeignKeyInfo struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+var schemaQuery = map[Dialect]struct {
+	tables, columns, indexes, foreignKeys string
+}{
+	dialect.MySQL: {
+		tables: `SELECT table_name AS name FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		columns: `SELECT column_name AS name, data_type AS data_type, is_nullable = 'YES' AS nullable, column_default AS ` + "`default`" + `
+FROM information_schema.columns
+WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`,
+		indexes: `SELECT index_name AS name, column_name AS column_name, non_unique = 0 AS is_unique, index_name = 'PRIMARY' AS is_primary
+FROM information_schema.statistics
+WHERE table_schema = DATABASE() AND table_name = ? ORDER BY index_name, seq_in_index`,
+		foreignKeys: `SELECT constraint_name AS name, column_name AS column_name, referenced_table_name AS ref_table, referenced_column_name AS ref_column
+FROM information_schema.key_column_usage
+WHERE table_schema = DATABASE() AND table_name = ? AND referenced_table_name IS NOT NULL
+ORDER BY constraint_name, ordinal_position`,
+	},
+	dialect.PostgreSQL: {
+		tables: `SELECT table_name AS name FROM information_schema.tables
+WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		columns: `SELECT column_name AS name, data_type, is_nullable = 'YES' AS nullable, column_default AS "default"
+FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position`,
+		indexes: `SELECT i.relname AS name, a.attname AS column_name, ix.indisunique AS is_unique, ix.indisprimary AS is_primary
+FROM pg_class t
+JOIN pg_index ix ON ix.indrelid = t.oid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE t.relname = ? AND t.relnamespace = current_schema()::regnamespace
+ORDER BY i.relname, k.ord`,
+		foreignKeys: `SELECT c.conname AS name, a.attname AS column_name, rt.relname AS ref_table, ra.attname AS ref_column
+FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_class rt ON rt.oid = c.confrelid
+JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refnum, ord) ON true
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refnum
+WHERE c.contype = 'f' AND t.relname = ? AND t.relnamespace = current_schema()::regnamespace
+ORDER BY c.conname, k.ord`,
+	},
+	dialect.SQLite3: {
+		tables: `SELECT name FROM sqlite_master
+WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`,
+		// INTEGER PRIMARY KEY columns are reported as nullable, so pk is checked too.
+		columns: `SELECT name, type AS data_type, "notnull" = 0 AND pk = 0 AS nullable, dflt_value AS "default"
+FROM pragma_table_info(?) ORDER BY cid`,
+		indexes: `SELECT il.name AS name, ii.name AS column_name, il."unique" AS is_unique, il.origin = 'pk' AS is_primary
+FROM pragma_index_list(?) il JOIN pragma_index_info(il.name) ii
+ORDER BY il.name, ii.seqno`,
+		foreignKeys: `SELECT CAST(id AS TEXT) AS name, "from" AS column_name, "table" AS ref_table, COALESCE("to", '') AS ref_column
+FROM pragma_foreign_key_list(?) ORDER BY id, seq`,
+	},
+	dialect.MSSQL: {
+		tables: `SELECT table_name AS name FROM information_schema.tables
+WHERE table_schema = SCHEMA_NAME() AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		columns: `SELECT column_name AS name, data_type, CASE WHEN is_nullable = 'YES' THEN 1 ELSE 0 END AS nullable, column_default AS "default"
+FROM information_schema.columns
+WHERE table_schema = SCHEMA_NAME() AND table_name = ? ORDER BY ordinal_position`,
+		indexes: `SELECT i.name AS name, c.name AS column_name, i.is_unique AS is_unique, i.is_primary_key AS is_primary
+FROM sys.indexes i
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE i.object_id = OBJECT_ID(?) ORDER BY i.name, ic.key_ordinal`,
+		foreignKeys: `SELECT fk.name AS name, c.name AS column_name, rt.name AS ref_table, rc.name AS ref_column
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE fk.parent_object_id = OBJECT_ID(?) ORDER BY fk.name, fkc.constraint_column_id`,
+	},
+}
+
+// Tables returns the names of all tables in the current schema.
+func (sess *Session) Tables(ctx context.Context) ([]string, error) {
+	q, ok := schemaQuery[sess.Dialect]
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	var tables []string
+	_, err := sess.SelectBySql(q.tables).LoadContext(ctx, &tables)
+	return tables, err
+}
+
+// Columns returns the columns of table in ordinal order.
+func (sess *Session) Columns(ctx context.Context, table string) ([]ColumnInfo, error) {
+	q, ok := schemaQuery[sess.Dialect]
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	var columns []ColumnInfo
+	_, err := sess.SelectBySql(q.columns, table).LoadContext(ctx, &columns)
+	return columns, err
+}
+
+// Indexes returns the indexes of table, including the primary key.
+//
+// In SQLite an INTEGER PRIMARY KEY is an alias of rowid and has no index.
+func (sess *Session) Indexes(ctx context.Context, table string) ([]IndexInfo, error) {
+	q, ok := schemaQuery[sess.Dialect]
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	var rows []struct {
+		Name       string
+		ColumnName string
+		IsUnique   bool
+		IsPrimary  bool
+	}
+	_, err := sess.SelectBySql(q.indexes, table).LoadContext(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []IndexInfo
+	for _, row := range rows {
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != row.Name {
+			indexes = append(indexes, IndexInfo{
+				Name:    row.Name,
+				Unique:  row.IsUnique,
+				Primary: row.IsPrimary,
+			})
+		}
+		index := &indexes[len(indexes)-1]
+		index.Columns = append(index.Columns, row.ColumnName)
+	}
+	return indexes, nil
+}
+
+// ForeignKeys returns the foreign keys declared on table.
+func (sess *Session) ForeignKeys(ctx context.Context, table string) ([]ForeignKeyInfo, error) {
+	q, ok := schemaQuery[sess.Dialect]
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	var rows []struct {
+		Name       string
+		ColumnName string
+		RefTable   string
+		RefColumn  string
+	}
+	_, err := sess.SelectBySql(q.foreignKeys, table).LoadContext(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	var fks []ForeignKeyInfo
+	for _, row := range rows {
+		if len(fks) == 0 || fks[len(fks)-1].Name != row.Name {
+			fks = append(fks, ForeignKeyInfo{
+				Name:     row.Name,
+				RefTable: row.RefTable,
+			})
+		}
+		fk := &fks[len(fks)-1]
+		fk.Columns = append(fk.Columns, row.ColumnName)
+		fk.RefColumns = append(fk.RefColumns, row.RefColumn)
+	}
+	return fks, nil
+}
+
+// HasColumns checks that table has all the named columns.
+// It returns the missing columns, which is useful for health checks at startup.
+func (sess *Session) HasColumns(ctx context.Context, table string, column ...string) ([]string, error) {
+	columns, err := sess.Columns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, want := range column {
+		found := false
+		for _, col := range columns {
+			if strings.EqualFold(col.Name, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing, nil
+}
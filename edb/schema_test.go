@@ -0,0 +1,65 @@
+package edb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite3Schema(t *testing.T) {
+	sess := memorySQLite(t)
+	ctx := context.Background()
+
+	for _, v := range []string{
+		`CREATE TABLE schema_groups (
+			id integer PRIMARY KEY,
+			code varchar(32) NOT NULL UNIQUE
+		)`,
+		`CREATE TABLE schema_people (
+			id integer PRIMARY KEY,
+			name varchar(255) NOT NULL DEFAULT 'anonymous',
+			email varchar(255),
+			group_id integer REFERENCES schema_groups (id)
+		)`,
+		`CREATE INDEX schema_people_name_email ON schema_people (name, email)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	tables, err := sess.Tables(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"schema_groups", "schema_people"}, tables)
+
+	columns, err := sess.Columns(ctx, "schema_people")
+	require.NoError(t, err)
+	require.Equal(t, []ColumnInfo{
+		{Name: "id", DataType: "integer"},
+		{Name: "name", DataType: "varchar(255)", Default: NewNullString("'anonymous'")},
+		{Name: "email", DataType: "varchar(255)", Nullable: true},
+		{Name: "group_id", DataType: "integer", Nullable: true},
+	}, columns)
+
+	indexes, err := sess.Indexes(ctx, "schema_people")
+	require.NoError(t, err)
+	require.Equal(t, []IndexInfo{
+		{Name: "schema_people_name_email", Columns: []string{"name", "email"}},
+	}, indexes)
+
+	indexes, err = sess.Indexes(ctx, "schema_groups")
+	require.NoError(t, err)
+	require.Len(t, indexes, 1)
+	require.Equal(t, []string{"code"}, indexes[0].Columns)
+	require.True(t, indexes[0].Unique)
+
+	fks, err := sess.ForeignKeys(ctx, "schema_people")
+	require.NoError(t, err)
+	require.Equal(t, []ForeignKeyInfo{
+		{Name: "0", Columns: []string{"group_id"}, RefTable: "schema_groups", RefColumns: []string{"id"}},
+	}, fks)
+
+	missing, err := sess.HasColumns(ctx, "schema_people", "id", "name", "phone")
+	require.NoError(t, err)
+	require.Equal(t, []string{"phone"}, missing)
+}
@@ -31,7 +31,14 @@ type SelectStmt struct {
 	LimitCount  int64
 	OffsetCount int64
 
+	SoftDelete *SoftDelete
+	// SafeIdent quotes the strings of Column, Table, Group and Order
+	// as identifiers, which can be written like `t.col`, `t.*`,
+	// `col AS alias` or `table alias`. Use Expr for raw sql.
+	SafeIdent bool
+
 	comments Comments
+	preloads []preload
 }
 
 type SelectBuilder = SelectStmt
@@ -62,7 +69,13 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 		switch col := col.(type) {
 		case string:
-			// FIXME: no quote ident
+			if b.SafeIdent {
+				s, err := quoteSelectIdent(d, col)
+				if err != nil {
+					return err
+				}
+				col = s
+			}
 			buf.WriteString(col)
 		default:
 			buf.WriteString(placeholder)
@@ -74,7 +87,13 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		buf.WriteString(" FROM ")
 		switch table := b.Table.(type) {
 		case string:
-			// FIXME: no quote ident
+			if b.SafeIdent {
+				s, err := quoteSelectIdent(d, table)
+				if err != nil {
+					return err
+				}
+				table = s
+			}
 			buf.WriteString(table)
 		default:
 			buf.WriteString(placeholder)
@@ -90,9 +109,10 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
-	if len(b.WhereCond) > 0 {
+	whereCond := b.SoftDelete.scope(b.Table, b.WhereCond)
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -104,7 +124,7 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
-			err := group.Build(d, buf)
+			err := b.buildIdent(d, buf, group)
 			if err != nil {
 				return err
 			}
@@ -125,7 +145,7 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
-			err := order.Build(d, buf)
+			err := b.buildIdent(d, buf, order)
 			if err != nil {
 				return err
 			}
@@ -159,6 +179,15 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 	return nil
 }
 
+// buildIdent builds a column of GROUP BY or ORDER BY,
+// which is quoted in safe identifier mode.
+func (b *SelectStmt) buildIdent(d Dialect, buf Buffer, builder Builder) error {
+	if ib, ok := builder.(identBuilder); ok && b.SafeIdent {
+		return ib.buildIdent(d, buf)
+	}
+	return builder.Build(d, buf)
+}
+
 // https://docs.microsoft.com/en-us/previous-versions/sql/sql-server-2012/ms188385(v=sql.110)
 func (b *SelectStmt) addMSSQLLimits(buf Buffer) {
 	limitCount := b.LimitCount
@@ -218,6 +247,8 @@ func (sess *Session) Select(column ...string) *SelectStmt {
 	b.runner = sess
 	b.EventReceiver = sess.EventReceiver
 	b.Dialect = sess.Dialect
+	b.SoftDelete = sess.SoftDelete
+	b.SafeIdent = sess.SafeIdent
 	return b
 }
 
@@ -227,6 +258,8 @@ func (tx *Tx) Select(column ...string) *SelectStmt {
 	b.runner = tx
 	b.EventReceiver = tx.EventReceiver
 	b.Dialect = tx.Dialect
+	b.SoftDelete = tx.SoftDelete
+	b.SafeIdent = tx.SafeIdent
 	return b
 }
 
@@ -299,7 +332,7 @@ func (b *SelectStmt) Having(query interface{}, value ...interface{}) *SelectStmt
 // GroupBy specifies columns for grouping.
 func (b *SelectStmt) GroupBy(col ...string) *SelectStmt {
 	for _, group := range col {
-		b.Group = append(b.Group, Expr(group))
+		b.Group = append(b.Group, groupItem(group))
 	}
 	return b
 }
@@ -316,7 +349,7 @@ func (b *SelectStmt) OrderDesc(col string) *SelectStmt {
 
 // OrderBy specifies columns for ordering.
 func (b *SelectStmt) OrderBy(col string) *SelectStmt {
-	b.Order = append(b.Order, Expr(col))
+	b.Order = append(b.Order, orderItem{column: col})
 	return b
 }
 
@@ -409,7 +442,7 @@ func (b *SelectStmt) LoadOneContext(ctx context.Context, value interface{}) erro
 	if count == 0 {
 		return ErrNotFound
 	}
-	return nil
+	return b.loadPreloads(ctx, value)
 }
 
 // LoadOne loads SQL result into go variable that is not a slice.
@@ -421,7 +454,38 @@ func (b *SelectStmt) LoadOne(value interface{}) error {
 }
 
 func (b *SelectStmt) LoadContext(ctx context.Context, value interface{}) (int, error) {
-	return query(ctx, b.runner, b.EventReceiver, b, b.Dialect, value)
+	count, err := query(ctx, b.runner, b.EventReceiver, b, b.Dialect, value)
+	if err != nil {
+		return count, err
+	}
+	return count, b.loadPreloads(ctx, value)
+}
+
+// Count returns the number of rows of the statement.
+//
+// The statement without ORDER BY, LIMIT and OFFSET is wrapped as a subquery
+// like `SELECT COUNT(*) FROM (...) AS count_query`. b is not modified.
+func (b *SelectStmt) Count(ctx context.Context) (int64, error) {
+	var count int64
+	_, err := query(ctx, b.runner, b.EventReceiver, b.countStmt(), b.Dialect, &count)
+	return count, err
+}
+
+// countStmt returns the statement that counts the rows of b.
+//
+// Without DISTINCT, GROUP BY and HAVING, the columns don't change the
+// number of rows, so they are replaced with 1. MySQL and MSSQL reject
+// the duplicate columns of a join like `SELECT *` in a derived table.
+func (b *SelectStmt) countStmt() *SelectStmt {
+	q := b.Clone()
+	q.Order = nil
+	q.LimitCount = -1
+	q.OffsetCount = -1
+	q.preloads = nil
+	if !q.IsDistinct && len(q.Group) == 0 && len(q.HavingCond) == 0 {
+		q.Column = []interface{}{Expr("1")}
+	}
+	return Select("COUNT(*)").From(q.As("count_query"))
 }
 
 // Load loads multi-row SQL result into a slice of go variables.
@@ -199,3 +199,172 @@ func TestPostgresArray(t *testing.T) {
 
 	require.Equal(t, []int64{1, 2, 3}, ns)
 }
+
+func TestSQLite3LoadJoinPrefix(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE users (id integer, name text)`,
+		`CREATE TABLE orders (id integer, user_id integer)`,
+		`INSERT INTO users VALUES (1, 'a')`,
+		`INSERT INTO orders VALUES (10, 1), (11, 1)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	type user struct {
+		ID   int64
+		Name string
+	}
+	type order struct {
+		ID int64
+	}
+	type userOrder struct {
+		User  user  `db:"user,prefix=user_"`
+		Order order `db:"order,prefix=order_"`
+	}
+	var rows []userOrder
+	_, err := sess.Select("u.id AS user_id", "u.name AS user_name", "o.id AS order_id").
+		From(I("users").As("u")).
+		Join(I("orders").As("o"), "o.user_id = u.id").
+		OrderAsc("o.id").
+		Load(&rows)
+	require.NoError(t, err)
+	require.Equal(t, []userOrder{
+		{User: user{ID: 1, Name: "a"}, Order: order{ID: 10}},
+		{User: user{ID: 1, Name: "a"}, Order: order{ID: 11}},
+	}, rows)
+
+	rows = nil
+	_, err = sess.Select(`u.id AS "user.id"`, `o.id AS "order.id"`).
+		From(I("users").As("u")).
+		Join(I("orders").As("o"), "o.user_id = u.id").
+		OrderAsc("o.id").
+		Limit(1).
+		Load(&rows)
+	require.NoError(t, err)
+	require.Equal(t, []userOrder{{User: user{ID: 1}, Order: order{ID: 10}}}, rows)
+}
+
+func TestSQLite3Preload(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE users (id integer, name text)`,
+		`CREATE TABLE orders (id integer, user_id integer)`,
+		`INSERT INTO users VALUES (1, 'a'), (2, 'b'), (3, 'c')`,
+		`INSERT INTO orders VALUES (10, 1), (11, 1), (12, 2)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	type order struct {
+		ID     int64
+		UserID int64
+	}
+	type user struct {
+		ID     int64
+		Name   string
+		Orders []order `db:"-"`
+	}
+
+	var users []*user
+	_, err := sess.Select("*").From("users").OrderAsc("id").
+		Preload("Orders", Select("*").From("orders o").OrderAsc("o.id"), "user_id", "id").
+		Load(&users)
+	require.NoError(t, err)
+	require.Equal(t, []*user{
+		{ID: 1, Name: "a", Orders: []order{{ID: 10, UserID: 1}, {ID: 11, UserID: 1}}},
+		{ID: 2, Name: "b", Orders: []order{{ID: 12, UserID: 2}}},
+		{ID: 3, Name: "c"},
+	}, users)
+
+	var u user
+	err = sess.Select("*").From("users").Where(Eq("id", 2)).
+		Preload("Orders", Select("id").From("orders"), "user_id", "id").
+		LoadOne(&u)
+	require.NoError(t, err)
+	require.Equal(t, user{ID: 2, Name: "b", Orders: []order{{ID: 12}}}, u)
+
+	err = sess.Select("*").From("users").Where(Eq("id", 2)).
+		Preload("Items", Select("*").From("orders"), "user_id", "id").
+		LoadOne(&u)
+	require.Equal(t, ErrInvalidPreload, err)
+}
+
+func TestSQLite3PreloadSoftDelete(t *testing.T) {
+	sess := memorySQLite(t)
+	sess.SoftDelete = &SoftDelete{Column: "deleted_at"}
+	sess.SafeIdent = true
+
+	for _, v := range []string{
+		`CREATE TABLE users (id integer, deleted_at timestamp)`,
+		`CREATE TABLE "order" (id integer, user_id integer, deleted_at timestamp)`,
+		`INSERT INTO users VALUES (1, NULL)`,
+		`INSERT INTO "order" VALUES (10, 1, NULL), (11, 1, '2020-01-01 00:00:00')`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	type order struct {
+		ID int64
+	}
+	type user struct {
+		ID     int64
+		Orders []order `db:"-"`
+	}
+
+	// the child is built without the session, but runs with its
+	// soft delete scope and quotes the reserved table name
+	var u user
+	err := sess.Select("id").From("users").
+		Preload("Orders", Select("id").From("order o"), "user_id", "id").
+		LoadOne(&u)
+	require.NoError(t, err)
+	require.Equal(t, user{ID: 1, Orders: []order{{ID: 10}}}, u)
+}
+
+func TestSelectSafeIdent(t *testing.T) {
+	for _, test := range []struct {
+		builder *SelectStmt
+		d       Dialect
+		query   string
+	}{
+		{
+			builder: Select("order", "u.group AS g", "u.*", Expr("COUNT(*)")).From("users u").
+				GroupBy("u.group").OrderAsc("order").OrderBy("u.id desc"),
+			d:     dialect.MySQL,
+			query: "SELECT `order`, `u`.`group` AS `g`, `u`.*, ? FROM `users` `u` GROUP BY `u`.`group` ORDER BY `order` ASC, `u`.`id` DESC",
+		},
+		{
+			builder: Select("*").From("dbo.users AS u").OrderDesc("u.name"),
+			d:       dialect.MSSQL,
+			query:   `SELECT * FROM "dbo"."users" AS "u" ORDER BY "u"."name" DESC`,
+		},
+	} {
+		test.builder.SafeIdent = true
+		buf := NewBuffer()
+		err := test.builder.Build(test.d, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+	}
+
+	for _, b := range []*SelectStmt{
+		Select("COUNT(*)").From("users"),
+		Select("id").From("users; DROP TABLE users"),
+		Select("id").From("users").OrderBy("FIELD(id, 1, 2)"),
+		Select("*").From("users").GroupBy("a b"),
+	} {
+		b.SafeIdent = true
+		err := b.Build(dialect.MySQL, NewBuffer())
+		require.Equal(t, ErrInvalidIdentifier, err)
+	}
+
+	buf := NewBuffer()
+	err := Select("COUNT(*)").From("users").OrderBy("FIELD(id, 1, 2)").Build(dialect.MySQL, buf)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT COUNT(*) FROM users ORDER BY FIELD(id, 1, 2)", buf.String())
+}
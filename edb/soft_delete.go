@@ -0,0 +1,67 @@
+package edb
+
+import "strings"
+
+// SoftDelete marks rows as deleted by setting a timestamp column to Now
+// instead of removing them.
+//
+// When it is set on a Session, DeleteFrom builds
+// `UPDATE ... SET deleted_at = ?`, while Select and Update
+// only see rows where `deleted_at IS NULL`.
+// Call Unscoped on a statement to bypass it.
+type SoftDelete struct {
+	// Column is the timestamp column, like deleted_at.
+	Column string
+	// Table limits soft deletion to these tables.
+	// If it is empty, every table is soft deleted.
+	Table []string
+}
+
+// column returns the soft delete column qualified by table alias.
+func (s *SoftDelete) column(table string) (string, bool) {
+	if s == nil || s.Column == "" {
+		return "", false
+	}
+	part := strings.Fields(table)
+	if len(part) == 0 {
+		return "", false
+	}
+	// table can be written as `users u` or `users AS u`
+	name, alias := part[0], part[len(part)-1]
+	if len(s.Table) > 0 && !IsSliceContainsString(name, s.Table...) {
+		return "", false
+	}
+	return alias + "." + s.Column, true
+}
+
+// scope adds `deleted_at IS NULL` to cond if table is soft deleted.
+func (s *SoftDelete) scope(table interface{}, cond []Builder) []Builder {
+	name, ok := table.(string)
+	if !ok {
+		return cond
+	}
+	column, ok := s.column(name)
+	if !ok {
+		return cond
+	}
+	// never write into the backing array of the statement's conditions
+	return append(cond[:len(cond):len(cond)], Eq(column, nil))
+}
+
+// Unscoped bypasses soft deletion.
+func (b *SelectStmt) Unscoped() *SelectStmt {
+	b.SoftDelete = nil
+	return b
+}
+
+// Unscoped bypasses soft deletion.
+func (b *UpdateStmt) Unscoped() *UpdateStmt {
+	b.SoftDelete = nil
+	return b
+}
+
+// Unscoped bypasses soft deletion, so rows are really deleted.
+func (b *DeleteStmt) Unscoped() *DeleteStmt {
+	b.SoftDelete = nil
+	return b
+}
@@ -0,0 +1,124 @@
+package edb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ego-plugin/store/edb/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	sd := &SoftDelete{Column: "deleted_at", Table: []string{"people"}}
+	for _, test := range []struct {
+		builder Builder
+		query   string
+		value   []interface{}
+	}{
+		{
+			builder: &SelectStmt{Column: []interface{}{"*"}, Table: "people", WhereCond: []Builder{Eq("a", 1)}, LimitCount: -1, OffsetCount: -1, SoftDelete: sd},
+			query:   "SELECT * FROM people WHERE (`a` = ?) AND (`people`.`deleted_at` IS NULL)",
+			value:   []interface{}{1},
+		},
+		{
+			builder: &SelectStmt{Column: []interface{}{"p.id"}, Table: "people AS p", LimitCount: -1, OffsetCount: -1, SoftDelete: sd},
+			query:   "SELECT p.id FROM people AS p WHERE (`p`.`deleted_at` IS NULL)",
+		},
+		{
+			builder: &SelectStmt{Column: []interface{}{"*"}, Table: "groups", LimitCount: -1, OffsetCount: -1, SoftDelete: sd},
+			query:   "SELECT * FROM groups",
+		},
+		{
+			builder: (&SelectStmt{Column: []interface{}{"*"}, Table: "people", LimitCount: -1, OffsetCount: -1, SoftDelete: sd}).Unscoped(),
+			query:   "SELECT * FROM people",
+		},
+		{
+			builder: &UpdateStmt{Table: "people", Value: map[string]interface{}{"a": 1}, LimitCount: -1, SoftDelete: sd},
+			query:   "UPDATE `people` SET `a` = ? WHERE (`people`.`deleted_at` IS NULL)",
+			value:   []interface{}{1},
+		},
+		{
+			builder: &DeleteStmt{Table: "people", WhereCond: []Builder{Eq("a", 1)}, LimitCount: -1, SoftDelete: sd},
+			query:   "UPDATE `people` SET `deleted_at` = ? WHERE (`a` = ?) AND (`people`.`deleted_at` IS NULL)",
+			value:   []interface{}{Now, 1},
+		},
+		{
+			builder: (&DeleteStmt{Table: "people", WhereCond: []Builder{Eq("a", 1)}, LimitCount: -1, SoftDelete: sd}).Unscoped(),
+			query:   "DELETE FROM `people` WHERE (`a` = ?)",
+			value:   []interface{}{1},
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		require.Equal(t, test.query, buf.String())
+		require.Equal(t, test.value, buf.Value())
+	}
+}
+
+func TestSoftDeleteDoesNotModifyWhereCond(t *testing.T) {
+	b := Select("*").From("people").Where(Eq("a", 1)).Where(Eq("b", 2))
+	b.SoftDelete = &SoftDelete{Column: "deleted_at"}
+	b.WhereCond = b.WhereCond[:1]
+
+	err := b.Build(dialect.MySQL, NewBuffer())
+	require.NoError(t, err)
+	require.Len(t, b.WhereCond, 1)
+	require.Equal(t, 2, cap(b.WhereCond))
+}
+
+func TestSQLite3SoftDelete(t *testing.T) {
+	sess := memorySQLite(t)
+	sess.SoftDelete = &SoftDelete{Column: "deleted_at"}
+	ctx := context.Background()
+
+	_, err := sess.CreateTable("soft_people").Columns(
+		Col("id", TypeInt).AutoIncrement(),
+		Col("name", TypeText),
+		Col("deleted_at", TypeTimestamp),
+	).ExecContext(ctx)
+	require.NoError(t, err)
+
+	_, err = sess.InsertInto("soft_people").Columns("name").Values("a").Values("b").ExecContext(ctx)
+	require.NoError(t, err)
+
+	result, err := sess.DeleteFrom("soft_people").Where(Eq("name", "a")).ExecContext(ctx)
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	var names []string
+	_, err = sess.Select("name").From("soft_people").LoadContext(ctx, &names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, names)
+
+	result, err = sess.Update("soft_people").Set("name", "c").ExecContext(ctx)
+	require.NoError(t, err)
+	n, err = result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	names = nil
+	_, err = sess.Select("name").From("soft_people").OrderAsc("id").Unscoped().LoadContext(ctx, &names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, names)
+
+	// an aliased soft delete with Limit is run in a rowid subquery
+	result, err = sess.DeleteFrom("soft_people p").Where(Neq("p.name", "x")).OrderAsc("p.id").Limit(1).ExecContext(ctx)
+	require.NoError(t, err)
+	n, err = result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	count, err := sess.Select("COUNT(*)").From("soft_people").ReturnInt64()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+
+	_, err = sess.DeleteFrom("soft_people p").Unscoped().Where(Eq("p.name", "a")).ExecContext(ctx)
+	require.NoError(t, err)
+	_, err = sess.DeleteFrom("soft_people").Unscoped().ExecContext(ctx)
+	require.NoError(t, err)
+	count, err = sess.Select("COUNT(*)").From("soft_people").Unscoped().ReturnInt64()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
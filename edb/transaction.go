@@ -11,7 +11,9 @@ type Tx struct {
 	EventReceiver
 	Dialect
 	*sql.Tx
-	Timeout time.Duration
+	Timeout    time.Duration
+	SoftDelete *SoftDelete
+	SafeIdent  bool
 }
 
 // GetTimeout returns timeout enforced in Tx.
@@ -32,6 +34,8 @@ func (sess *Session) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, err
 		Dialect:       sess.Dialect,
 		Tx:            tx,
 		Timeout:       sess.GetTimeout(),
+		SoftDelete:    sess.SoftDelete,
+		SafeIdent:     sess.SafeIdent,
 	}, nil
 }
 
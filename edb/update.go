@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"reflect"
+	"sort"
 	"strconv"
-	"strings"
+
+	"github.com/ego-plugin/store/edb/dialect"
 )
 
 // UpdateStmt builds `UPDATE ...`.
@@ -16,11 +18,16 @@ type UpdateStmt struct {
 
 	raw
 
-	Table        string
-	Value        map[string]interface{}
+	Table     string
+	JoinTable []tableJoin
+	Value     map[string]interface{}
+	// Column is the order of the columns in Value, which are set by Set.
+	// Columns of Value not in Column are written after them, sorted.
+	Column       []string
 	WhereCond    []Builder
 	ReturnColumn []string
 	LimitCount   int64
+	SoftDelete   *SoftDelete
 	comments     Comments
 }
 
@@ -44,12 +51,33 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
+	whereCond := b.SoftDelete.scope(b.Table, b.WhereCond)
+
 	buf.WriteString("UPDATE ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	if len(b.JoinTable) > 0 {
+		switch d {
+		case dialect.MySQL:
+			if b.LimitCount >= 0 {
+				return ErrNotSupported
+			}
+			buf.WriteString(quoteTable(d, b.Table))
+			for _, join := range b.JoinTable {
+				err := join.build(d, buf, false)
+				if err != nil {
+					return err
+				}
+			}
+		case dialect.MSSQL:
+			buf.WriteString(d.QuoteIdent(tableAlias(b.Table)))
+		default:
+			buf.WriteString(quoteTargetTable(d, b.Table))
+		}
+	} else {
+		buf.WriteString(quoteTargetTable(d, b.Table))
+	}
 	buf.WriteString(" SET ")
 
-	i := 0
-	for col, v := range b.Value {
+	for i, col := range b.setColumns() {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
@@ -57,14 +85,35 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 		buf.WriteString(" = ")
 		buf.WriteString(placeholder)
 
-		buf.WriteValue(v)
+		buf.WriteValue(b.Value[col])
+	}
 
-		i++
+	if len(b.JoinTable) > 0 {
+		switch d {
+		case dialect.MySQL:
+		case dialect.MSSQL:
+			buf.WriteString(" FROM ")
+			buf.WriteString(quoteTable(d, b.Table))
+			for _, join := range b.JoinTable {
+				err := join.build(d, buf, false)
+				if err != nil {
+					return err
+				}
+			}
+		default:
+			// join conditions are moved to WHERE
+			buf.WriteString(" FROM ")
+			on, err := buildJoinList(d, buf, b.JoinTable)
+			if err != nil {
+				return err
+			}
+			whereCond = append(on, whereCond...)
+		}
 	}
 
-	if len(b.WhereCond) > 0 {
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -103,6 +152,7 @@ func (sess *Session) Update(table string) *UpdateStmt {
 	b.runner = sess
 	b.EventReceiver = sess.EventReceiver
 	b.Dialect = sess.Dialect
+	b.SoftDelete = sess.SoftDelete
 	return b
 }
 
@@ -112,6 +162,7 @@ func (tx *Tx) Update(table string) *UpdateStmt {
 	b.runner = tx
 	b.EventReceiver = tx.EventReceiver
 	b.Dialect = tx.Dialect
+	b.SoftDelete = tx.SoftDelete
 	return b
 }
 
@@ -145,6 +196,91 @@ func (tx *Tx) UpdateBySql(query string, value ...interface{}) *UpdateStmt {
 	return b
 }
 
+// tableJoin is a table joined to UPDATE or DELETE.
+type tableJoin struct {
+	table interface{}
+	// on is nil for a table added with From
+	on interface{}
+}
+
+// build writes the table with its join condition,
+// or only the table if list is true.
+func (j tableJoin) build(d Dialect, buf Buffer, list bool) error {
+	if !list {
+		if j.on == nil {
+			buf.WriteString(", ")
+		} else {
+			buf.WriteString(" JOIN ")
+		}
+	}
+	switch table := j.table.(type) {
+	case string:
+		buf.WriteString(quoteTable(d, table))
+	default:
+		buf.WriteString(placeholder)
+		buf.WriteValue(table)
+	}
+	if list || j.on == nil {
+		return nil
+	}
+	buf.WriteString(" ON ")
+	switch on := j.on.(type) {
+	case string:
+		buf.WriteString(on)
+	case Builder:
+		buf.WriteString(placeholder)
+		buf.WriteValue(on)
+	}
+	return nil
+}
+
+// buildJoinList writes joined tables as a list like `b, c`,
+// and returns their join conditions.
+func buildJoinList(d Dialect, buf Buffer, joins []tableJoin) ([]Builder, error) {
+	var on []Builder
+	for i, join := range joins {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		err := join.build(d, buf, true)
+		if err != nil {
+			return nil, err
+		}
+		switch cond := join.on.(type) {
+		case string:
+			on = append(on, Expr(cond))
+		case Builder:
+			on = append(on, cond)
+		}
+	}
+	return on, nil
+}
+
+// Join joins table to update rows with the columns of table.
+// table can be string or Builder, like a subquery with As.
+// on can be Builder or string.
+//
+// It builds `UPDATE a JOIN b ON ... SET` on MySQL, and
+// `UPDATE a SET ... FROM a JOIN b ON ...` on MSSQL, where the updated
+// table is referred by its alias. On PostgreSQL and SQLite, it builds
+// `UPDATE a SET ... FROM b WHERE ...` with on moved to WHERE,
+// so Set columns must not be qualified with the table.
+func (b *UpdateStmt) Join(table, on interface{}) *UpdateStmt {
+	b.JoinTable = append(b.JoinTable, tableJoin{table: table, on: on})
+	return b
+}
+
+// From adds table to update rows with the columns of table,
+// which is usually matched in Where. table can be string or Builder,
+// like a subquery with As.
+//
+// It builds `UPDATE a, b SET` on MySQL, `UPDATE a SET ... FROM a, b` on
+// MSSQL, and `UPDATE a SET ... FROM b` on PostgreSQL and SQLite.
+func (b *UpdateStmt) From(table interface{}) *UpdateStmt {
+	b.JoinTable = append(b.JoinTable, tableJoin{table: table})
+	return b
+}
+
 // Where adds a where condition.
 // query can be Builder or string. value is used only if query type is string.
 func (b *UpdateStmt) Where(query interface{}, value ...interface{}) *UpdateStmt {
@@ -163,64 +299,81 @@ func (b *UpdateStmt) Returning(column ...string) *UpdateStmt {
 	return b
 }
 
+// setColumns returns the columns of Value in the order of Column,
+// followed by the other columns sorted.
+func (b *UpdateStmt) setColumns() []string {
+	columns := make([]string, 0, len(b.Value))
+	seen := make(map[string]bool, len(b.Value))
+	for _, col := range b.Column {
+		if _, ok := b.Value[col]; ok && !seen[col] {
+			seen[col] = true
+			columns = append(columns, col)
+		}
+	}
+	n := len(columns)
+	for col := range b.Value {
+		if !seen[col] {
+			columns = append(columns, col)
+		}
+	}
+	sort.Strings(columns[n:])
+	return columns
+}
+
 // Set updates column with value.
+// Columns are written in the order they are first set.
 func (b *UpdateStmt) Set(column string, value interface{}) *UpdateStmt {
+	if _, ok := b.Value[column]; !ok {
+		b.Column = append(b.Column, column)
+	}
 	b.Value[column] = value
 	return b
 }
 
+// SetExpr updates column with expr, like `Expr("GREATEST(x, ?)", 1)`.
+func (b *UpdateStmt) SetExpr(column string, expr Builder) *UpdateStmt {
+	return b.Set(column, expr)
+}
+
 // SetMap specifies a map of (column, value) to update in bulk.
+// The columns are set in sorted order.
 func (b *UpdateStmt) SetMap(m map[string]interface{}) *UpdateStmt {
-	for col, val := range m {
-		b.Set(col, val)
+	columns := make([]string, 0, len(m))
+	for col := range m {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+	for _, col := range columns {
+		b.Set(col, m[col])
 	}
 	return b
 }
 
 // ScanStruct 扫描struct按column绑定值 (传入值, 表列名) tag 绑定db名
+//
+// db tag options are honored: readonly, pk and autocreatetime fields are
+// skipped, omitempty fields are skipped if they are the zero value, and
+// autoupdatetime fields are set to Now.
 func (b *UpdateStmt) ScanStruct(v interface{}, column ...string) *UpdateStmt {
-	valueType := reflect.TypeOf(v)
-	valueValue := reflect.ValueOf(v)
-	if valueType.Kind() == reflect.Ptr {
-		valueType = valueType.Elem()
-		valueValue = valueValue.Elem()
-	}
-	if valueType.Kind() != reflect.Struct {
+	valueValue := reflect.Indirect(reflect.ValueOf(v))
+	if valueValue.Kind() != reflect.Struct {
 		return b
 	}
-	columnLen := len(column)
-	for i := 0; i < valueType.NumField(); i++ {
-		typeField := valueType.Field(i)  // struct取行类型
-		valueField := valueValue.Field(i) // struct取行值
-		// 取列名
-		columnName := typeField.Tag.Get("db")
-		columnNameList := strings.Split(columnName, ",")
-		columnName = columnNameList[0]
-		// 列名为空转到下一行
-		if columnName == "" || columnName == "-" || columnName == "id" || columnName == "ID" {
-			continue
-		}
-		// 检查列是否存在
-		if columnLen > 0 && !IsSliceContainsString(columnName, column...) {
-			continue
-		}
-		if valueField.CanSet() {
-			b.Set(columnName, valueField.Interface())
-		}
+	columns, values := structValues(valueValue, column, true)
+	for i := range columns {
+		b.Set(columns[i], values[i])
 	}
 	return b
 }
 
 // IncrBy increases column by value
 func (b *UpdateStmt) IncrBy(column string, value interface{}) *UpdateStmt {
-	b.Value[column] = Expr("? + ?", I(column), value)
-	return b
+	return b.SetExpr(column, Expr("? + ?", I(column), value))
 }
 
 // DecrBy decreases column by value
 func (b *UpdateStmt) DecrBy(column string, value interface{}) *UpdateStmt {
-	b.Value[column] = Expr("? - ?", I(column), value)
-	return b
+	return b.SetExpr(column, Expr("? - ?", I(column), value))
 }
 
 func (b *UpdateStmt) Limit(n uint64) *UpdateStmt {
@@ -17,6 +17,76 @@ func TestUpdateStmt(t *testing.T) {
 	require.Equal(t, []interface{}{1, 2}, buf.Value())
 }
 
+func TestUpdateSetOrder(t *testing.T) {
+	b := Update("t").Set("c", 1).Set("a", 2).SetExpr("b", Expr("GREATEST(b, ?)", 3)).IncrBy("n", 1).Set("c", 4)
+	b.Value["z"] = 5
+	b.Value["y"] = 6
+	for i := 0; i < 10; i++ {
+		buf := NewBuffer()
+		err := b.Build(dialect.MySQL, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE `t` SET `c` = 4, `a` = 2, `b` = GREATEST(b, 3), `n` = `n` + 1, `y` = 6, `z` = 5", query)
+	}
+
+	b = Update("t").SetMap(map[string]interface{}{"b": 1, "a": 2, "c": 3})
+	require.Equal(t, []string{"a", "b", "c"}, b.Column)
+}
+
+func TestUpdateScanStructTags(t *testing.T) {
+	b := Update("t").ScanStruct(&taggedRecord{ID: 1, Name: "a", Version: 1})
+	require.Equal(t, map[string]interface{}{"name": "a", "updated_at": Now}, b.Value)
+
+	b = Update("t").ScanStruct(taggedRecord{Name: "a", Nickname: "b"}, "nickname", "created_at")
+	require.Equal(t, map[string]interface{}{"nickname": "b"}, b.Value)
+}
+
+func TestUpdateJoin(t *testing.T) {
+	sub := Select("user_id", "COUNT(*) AS n").From("orders").GroupBy("user_id").As("o")
+	for _, test := range []struct {
+		builder *UpdateStmt
+		d       Dialect
+		query   string
+	}{
+		{
+			builder: Update("users u").Join("accounts a", "a.user_id = u.id").Set("u.active", true).Where(Eq("a.closed", false)),
+			d:       dialect.MySQL,
+			query:   "UPDATE `users` `u` JOIN `accounts` `a` ON a.user_id = u.id SET `u`.`active` = 1 WHERE (`a`.`closed` = 0)",
+		},
+		{
+			builder: Update("users u").Join("accounts a", "a.user_id = u.id").Set("active", true).Where(Eq("a.closed", false)),
+			d:       dialect.PostgreSQL,
+			query:   `UPDATE "users" "u" SET "active" = TRUE FROM "accounts" "a" WHERE (a.user_id = u.id) AND ("a"."closed" = FALSE)`,
+		},
+		{
+			builder: Update("users u").Join(sub, "o.user_id = u.id").Set("order_count", I("o.n")),
+			d:       dialect.SQLite3,
+			query:   `UPDATE "users" AS "u" SET "order_count" = "o"."n" FROM (SELECT user_id, COUNT(*) AS n FROM orders GROUP BY user_id) AS "o" WHERE (o.user_id = u.id)`,
+		},
+		{
+			builder: Update("users u").Join("accounts a", "a.user_id = u.id").Set("u.active", true),
+			d:       dialect.MSSQL,
+			query:   `UPDATE "u" SET "u"."active" = 1 FROM "users" "u" JOIN "accounts" "a" ON a.user_id = u.id`,
+		},
+		{
+			builder: Update("users").From("accounts").Set("users.active", true).Where("accounts.user_id = users.id"),
+			d:       dialect.MySQL,
+			query:   "UPDATE `users`, `accounts` SET `users`.`active` = 1 WHERE (accounts.user_id = users.id)",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(test.d, buf)
+		require.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	err := Update("users u").Join("accounts a", "a.user_id = u.id").Set("active", true).Limit(1).Build(dialect.MySQL, NewBuffer())
+	require.Equal(t, ErrNotSupported, err)
+}
+
 func BenchmarkUpdateValuesSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -60,3 +130,36 @@ func TestUpdateIncrBy(t *testing.T) {
 
 	require.Equal(t, "UPDATE `table` SET `a` = `a` + 1 WHERE (`b` = 2)", sqlstr)
 }
+
+func TestSQLite3UpdateJoin(t *testing.T) {
+	sess := memorySQLite(t)
+
+	for _, v := range []string{
+		`CREATE TABLE users (id integer, order_count integer)`,
+		`CREATE TABLE orders (id integer, user_id integer)`,
+		`INSERT INTO users VALUES (1, 0), (2, 0), (3, 0)`,
+		`INSERT INTO orders VALUES (10, 1), (11, 1), (12, 2)`,
+	} {
+		_, err := sess.Exec(v)
+		require.NoError(t, err)
+	}
+
+	sub := Select("user_id", "COUNT(*) AS n").From("orders").GroupBy("user_id").As("o")
+	_, err := sess.Update("users").Join(sub, "o.user_id = users.id").Set("order_count", I("o.n")).Exec()
+	require.NoError(t, err)
+
+	var counts []int64
+	_, err = sess.Select("order_count").From("users").OrderAsc("id").Load(&counts)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 1, 0}, counts)
+
+	_, err = sess.Update("users u").Join(sub, "o.user_id = u.id").Set("order_count", Expr("o.n * 10")).Exec()
+	require.NoError(t, err)
+	_, err = sess.Update("users u").Set("order_count", 5).Where("u.id = ?", 3).Exec()
+	require.NoError(t, err)
+
+	counts = nil
+	_, err = sess.Select("order_count").From("users").OrderAsc("id").Load(&counts)
+	require.NoError(t, err)
+	require.Equal(t, []int64{20, 10, 5}, counts)
+}
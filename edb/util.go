@@ -4,8 +4,13 @@ import (
 	"database/sql/driver"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
+// NameMapping maps the name of a struct field without a db tag to its
+// column name. It must be set before the first query, because the column
+// names of each struct type are cached the first time the type is used.
 var NameMapping = camelCaseToSnakeCase
 
 func isUpper(b byte) bool {
@@ -47,42 +52,279 @@ var (
 	typeValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
 )
 
+// fieldTag is a parsed `db` struct tag like `db:"name,omitempty"`.
+//
+// The options after the column name are:
+//
+//	omitempty       not written if the field is the zero value
+//	readonly        never written
+//	autocreatetime  written as Now on insert if the field is the zero value
+//	autoupdatetime  written as Now on update, and on insert like autocreatetime
+//	pk              primary key; not written on update, and not written on
+//	                insert if the field is the zero value
+//	prefix=p        on a nested struct, its fields are loaded from the
+//	                columns named with the prefix p
+type fieldTag struct {
+	name           string
+	omitEmpty      bool
+	readOnly       bool
+	autoCreateTime bool
+	autoUpdateTime bool
+	pk             bool
+	prefix         string
+}
+
+func parseTag(tag string) fieldTag {
+	opts := strings.Split(tag, ",")
+	t := fieldTag{name: opts[0]}
+	for _, opt := range opts[1:] {
+		opt = strings.TrimSpace(opt)
+		switch opt {
+		case "omitempty":
+			t.omitEmpty = true
+		case "readonly":
+			t.readOnly = true
+		case "autocreatetime":
+			t.autoCreateTime = true
+		case "autoupdatetime":
+			t.autoUpdateTime = true
+		case "pk":
+			t.pk = true
+		default:
+			if strings.HasPrefix(opt, "prefix=") {
+				t.prefix = strings.TrimPrefix(opt, "prefix=")
+			}
+		}
+	}
+	return t
+}
+
+// structValues returns the columns and values to write from the tagged
+// fields of a struct, honoring the tag options of fieldTag.
+// If column is not empty, only those columns are returned.
+func structValues(v reflect.Value, column []string, update bool) ([]string, []interface{}) {
+	var columns []string
+	var values []interface{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		tag := field.Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		ft := parseTag(tag)
+		if ft.name == "" {
+			ft.name = NameMapping(field.Name)
+		}
+		if len(column) > 0 && !IsSliceContainsString(ft.name, column...) {
+			continue
+		}
+		fieldValue := v.Field(i)
+		isZero := fieldValue.IsZero()
+		if ft.readOnly || ft.omitEmpty && isZero {
+			continue
+		}
+		var value interface{} = fieldValue.Interface()
+		if update {
+			if ft.pk || strings.EqualFold(ft.name, "id") || ft.autoCreateTime {
+				continue
+			}
+			if ft.autoUpdateTime {
+				value = Now
+			}
+		} else {
+			if ft.pk && isZero || !ft.pk && ft.name == "id" {
+				continue
+			}
+			if (ft.autoCreateTime || ft.autoUpdateTime) && isZero {
+				value = Now
+			}
+		}
+		columns = append(columns, ft.name)
+		values = append(values, value)
+	}
+	return columns, values
+}
+
+// tagCache is map[reflect.Type][]fieldTag, shared by all tagStores.
+// The names are mapped with NameMapping when a type is first cached.
+var tagCache sync.Map
+
+// pathCache is map[pathKey][][][]int, shared by all tagStores.
+//
+// It has an entry for each struct type and column list, so it stops
+// growing at maxPathCache entries, and the paths of new column lists,
+// like those of dynamic selects, are then built for each query.
+var pathCache sync.Map
+
+// pathCacheLen is the number of entries in pathCache.
+var pathCacheLen atomic.Int64
+
+const maxPathCache = 4096
+
+// pathKey is a struct type and the column names looked up in it.
+type pathKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// fieldPaths are the index paths of the fields matching each column name.
+type fieldPaths struct {
+	name  []string
+	paths [][][]int
+}
+
+// tagStore looks up struct fields by column name.
+//
+// Tags and field index paths are cached process-wide, while a tagStore
+// remembers the paths of the column names it last used for each type,
+// so scanning rows of one query does not touch the shared cache.
 type tagStore struct {
-	m map[reflect.Type][]string
+	m map[reflect.Type]*fieldPaths
 }
 
 func newTagStore() *tagStore {
 	return &tagStore{
-		m: make(map[reflect.Type][]string),
+		m: make(map[reflect.Type]*fieldPaths),
 	}
 }
 
-func (s *tagStore) get(t reflect.Type) []string {
+func (s *tagStore) get(t reflect.Type) []fieldTag {
 	if t.Kind() != reflect.Struct {
 		return nil
 	}
-	if _, ok := s.m[t]; !ok {
-		l := make([]string, t.NumField())
+	if l, ok := tagCache.Load(t); ok {
+		return l.([]fieldTag)
+	}
+	l := make([]fieldTag, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			// unexported
+			continue
+		}
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			// ignore
+			continue
+		}
+		ft := parseTag(tag)
+		if ft.name == "" {
+			// no tag, but we can record the field name
+			ft.name = NameMapping(field.Name)
+		}
+		l[i] = ft
+	}
+	tagCache.Store(t, l)
+	return l
+}
+
+// columnTag returns the tag of the field of t named column,
+// or the zero fieldTag if there is none.
+func (s *tagStore) columnTag(t reflect.Type, column string) fieldTag {
+	for _, tag := range s.get(t) {
+		if tag.name == column {
+			return tag
+		}
+	}
+	return fieldTag{}
+}
+
+// pkName returns the column name of the field tagged with pk,
+// or "id" if there is none.
+func (s *tagStore) pkName(t reflect.Type) string {
+	for _, tag := range s.get(t) {
+		if tag.pk {
+			return tag.name
+		}
+	}
+	return "id"
+}
+
+// paths returns the index paths of the fields matching each column name
+// of t, in the order they are found by a depth-first walk.
+func (s *tagStore) paths(t reflect.Type, name []string) [][][]int {
+	if fp, ok := s.m[t]; ok && equalStrings(fp.name, name) {
+		return fp.paths
+	}
+	key := pathKey{typ: t, name: strings.Join(name, "\x00")}
+	var paths [][][]int
+	if v, ok := pathCache.Load(key); ok {
+		paths = v.([][][]int)
+	} else {
+		paths = make([][][]int, len(name))
+		s.buildPaths(t, name, nil, paths, make(map[reflect.Type]bool))
+		if pathCacheLen.Load() < maxPathCache {
+			if _, loaded := pathCache.LoadOrStore(key, paths); !loaded {
+				pathCacheLen.Add(1)
+			}
+		}
+	}
+	s.m[t] = &fieldPaths{name: append([]string(nil), name...), paths: paths}
+	return paths
+}
+
+func (s *tagStore) buildPaths(t reflect.Type, name []string, index []int, paths [][][]int, visiting map[reflect.Type]bool) {
+	if t.Implements(typeValuer) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s.buildPaths(t.Elem(), name, index, paths, visiting)
+	case reflect.Struct:
+		// stop at recursive types like `Parent *Node`
+		if visiting[t] {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		l := s.get(t)
 		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			if field.PkgPath != "" && !field.Anonymous {
-				// unexported
+			tag := l[i]
+			if tag.name == "" {
 				continue
 			}
-			tag := field.Tag.Get("db")
-			if tag == "-" {
-				// ignore
-				continue
+			fieldIndex := append(index[:len(index):len(index)], i)
+			for j, want := range name {
+				if want == tag.name {
+					paths[j] = append(paths[j], fieldIndex)
+				}
 			}
-			if tag == "" {
-				// no tag, but we can record the field name
-				tag = NameMapping(field.Name)
+			s.buildPaths(t.Field(i).Type, nestedName(tag, name), fieldIndex, paths, visiting)
+		}
+	}
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex,
+// but it fails instead of panicking on a nil pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
 			}
-			l[i] = tag
+			v = v.Elem()
 		}
-		s.m[t] = l
+		v = v.Field(i)
 	}
-	return s.m[t]
+	return v, true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *tagStore) findPtr(value reflect.Value, name []string, ptr []interface{}) error {
@@ -90,6 +332,12 @@ func (s *tagStore) findPtr(value reflect.Value, name []string, ptr []interface{}
 		ptr[0] = value.Addr().Interface()
 		return nil
 	}
+	if value.CanAddr() {
+		if dest, ok := bigDest(value.Addr().Interface()).(bigScanner); ok {
+			ptr[0] = dest
+			return nil
+		}
+	}
 	switch value.Kind() {
 	case reflect.Struct:
 		s.findValueByName(value, name, ptr, true)
@@ -106,40 +354,60 @@ func (s *tagStore) findPtr(value reflect.Value, name []string, ptr []interface{}
 }
 
 func (s *tagStore) findValueByName(value reflect.Value, name []string, ret []interface{}, retPtr bool) {
-	if value.Type().Implements(typeValuer) {
-		return
-	}
-	switch value.Kind() {
-	case reflect.Ptr:
-		if value.IsNil() {
-			return
+	paths := s.paths(value.Type(), name)
+	for i := range name {
+		if ret[i] != nil {
+			continue
 		}
-		s.findValueByName(value.Elem(), name, ret, retPtr)
-	case reflect.Struct:
-		l := s.get(value.Type())
-		for i := 0; i < value.NumField(); i++ {
-			tag := l[i]
-			if tag == "" {
+		for _, path := range paths[i] {
+			fieldValue, ok := fieldByIndex(value, path)
+			if !ok {
 				continue
 			}
-			fieldValue := value.Field(i)
-			for i, want := range name {
-				if want != tag {
-					continue
-				}
-				if ret[i] == nil {
-					if retPtr {
-						ret[i] = fieldValue.Addr().Interface()
-					} else {
-						ret[i] = fieldValue
-					}
-				}
+			if retPtr {
+				ret[i] = bigDest(fieldValue.Addr().Interface())
+			} else {
+				ret[i] = fieldValue
 			}
-			s.findValueByName(fieldValue, name, ret, retPtr)
+			break
 		}
 	}
 }
 
+// nestedName returns the column names seen by the fields of a nested struct.
+//
+// A column like "user.id" is resolved to the field "id" of the nested struct
+// named "user". If the nested struct has a prefix like
+// `db:"user,prefix=user_"`, only columns with the prefix like "user_id" are
+// resolved, with the prefix removed. Otherwise other columns are matched
+// by their bare name.
+func nestedName(tag fieldTag, name []string) []string {
+	var nested []string
+	for i, want := range name {
+		var n string
+		switch {
+		case tag.prefix != "" && strings.HasPrefix(want, tag.prefix):
+			n = want[len(tag.prefix):]
+		case strings.HasPrefix(want, tag.name+"."):
+			n = want[len(tag.name)+1:]
+		case tag.prefix == "":
+			n = want
+		}
+		if nested == nil {
+			if n == want {
+				continue
+			}
+			nested = make([]string, len(name))
+			copy(nested, name[:i])
+		}
+		nested[i] = n
+	}
+	if nested == nil {
+		return name
+	}
+	return nested
+}
+
 // IsSliceContainsString method checks given string in the slice if found returns
 // true otherwise false.
 func IsSliceContainsString(search string, strSlice ...string) bool {
@@ -82,6 +82,20 @@ func TestFindValueByName(t *testing.T) {
 			name: []string{"test"},
 			want: []string{"test"},
 		},
+		{
+			in: struct {
+				IntVal int `db:"test,omitempty"`
+			}{},
+			name: []string{"test"},
+			want: []string{"test"},
+		},
+		{
+			in: struct {
+				IntVal int `db:",readonly"`
+			}{},
+			name: []string{"int_val"},
+			want: []string{"int_val"},
+		},
 		{
 			in: struct {
 				IntVal int `db:"-"`
@@ -112,3 +126,53 @@ func TestFindValueByName(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestFindValueByNameNested(t *testing.T) {
+	type user struct {
+		ID   int64
+		Name string
+	}
+	type order struct {
+		ID int64
+	}
+	var v struct {
+		User  user  `db:"user,prefix=user_"`
+		Order order `db:"order"`
+	}
+	name := []string{"user_id", "user_name", "order.id", "id"}
+	found := make([]interface{}, len(name))
+	s := newTagStore()
+	s.findValueByName(reflect.ValueOf(&v).Elem(), name, found, true)
+	require.Equal(t, []interface{}{&v.User.ID, &v.User.Name, &v.Order.ID, &v.Order.ID}, found)
+	require.Equal(t, []string{"user_id", "user_name", "order.id", "id"}, name)
+}
+
+func TestFindValueByNameCache(t *testing.T) {
+	type node struct {
+		ID     int64
+		Parent *node
+	}
+	type wrapper struct {
+		Ptr *struct {
+			Name string
+		}
+		Node node
+		Name string
+	}
+	name := []string{"name", "id"}
+
+	// a nil pointer falls back to the next matching field
+	var v wrapper
+	found := make([]interface{}, len(name))
+	s := newTagStore()
+	s.findValueByName(reflect.ValueOf(&v).Elem(), name, found, true)
+	require.Equal(t, []interface{}{&v.Name, &v.Node.ID}, found)
+
+	v.Ptr = &struct{ Name string }{}
+	found = make([]interface{}, len(name))
+	newTagStore().findValueByName(reflect.ValueOf(&v).Elem(), name, found, true)
+	require.Equal(t, []interface{}{&v.Ptr.Name, &v.Node.ID}, found)
+
+	paths := s.paths(reflect.TypeOf(v), name)
+	require.Equal(t, [][][]int{{{0, 0}, {2}}, {{1, 0}}}, paths)
+}